@@ -0,0 +1,130 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrFailed is the rejection of [Accumulator.FailNow] when no errors were appended.
+var ErrFailed = errors.New("failed")
+
+// Accumulator collects errors for a [Promise], rejecting it with all of them at once. This suits producers that
+// validate many things and want to report every problem instead of the first.
+type Accumulator[R any] struct {
+	p     Promise[R]
+	mu    sync.Mutex
+	errs  []error
+	timer *time.Timer
+	gen   int // incremented for each deadline, so replaced timers are ignored
+	done  bool
+}
+
+// NewAccumulator returns an [Accumulator] fulfilling p.
+func NewAccumulator[R any](p Promise[R]) *Accumulator[R] {
+	return &Accumulator[R]{p: p}
+}
+
+// AppendError records err. Nil errors and errors appended after the promise is settled are ignored.
+func (a *Accumulator[R]) AppendError(err error) {
+	if err == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.done {
+		a.errs = append(a.errs, err)
+	}
+}
+
+// Len returns the number of recorded errors.
+func (a *Accumulator[R]) Len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.errs)
+}
+
+// FailNow rejects the promise with all recorded errors joined, or [ErrFailed] when there are none.
+func (a *Accumulator[R]) FailNow() {
+	a.settle(0, func(errs []error) result.Result[R] {
+		if len(errs) == 0 {
+			return result.OfError[R](ErrFailed)
+		}
+
+		return result.OfError[R](errors.Join(errs...))
+	})
+}
+
+// Resolve resolves the promise with value when no errors were recorded, otherwise rejects it like
+// [Accumulator.FailNow].
+func (a *Accumulator[R]) Resolve(value R) {
+	a.settle(0, func(errs []error) result.Result[R] {
+		if len(errs) == 0 {
+			return result.OfValue(value)
+		}
+
+		return result.OfError[R](errors.Join(errs...))
+	})
+}
+
+// SetDeadline rejects the promise at t with the recorded errors and [context.DeadlineExceeded], unless it is
+// settled before. Calling it again replaces the previous deadline.
+func (a *Accumulator[R]) SetDeadline(t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.done {
+		return
+	}
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+
+	a.gen++
+	gen := a.gen
+	a.timer = time.AfterFunc(time.Until(t), func() {
+		a.settle(gen, func(errs []error) result.Result[R] {
+			return result.OfError[R](errors.Join(append(errs, context.DeadlineExceeded)...))
+		})
+	})
+}
+
+// settle completes the promise once with the result of fn. A non-zero gen settles only for the current deadline.
+func (a *Accumulator[R]) settle(gen int, fn func(errs []error) result.Result[R]) {
+	a.mu.Lock()
+	if a.done || gen != 0 && gen != a.gen {
+		a.mu.Unlock()
+
+		return
+	}
+	a.done = true
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	errs := a.errs
+	a.mu.Unlock()
+
+	a.p.complete(fn(errs))
+}
@@ -0,0 +1,104 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccumulatorFailNow(t *testing.T) {
+	t.Parallel()
+
+	// given
+	errOther := errors.New("other")
+	p, f := async.New[int]()
+	a := async.NewAccumulator(p)
+
+	// when
+	a.AppendError(errTest)
+	a.AppendError(nil)
+	a.AppendError(errOther)
+	a.FailNow()
+	a.AppendError(errTest) // ignored
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errOther)
+	assert.Equal(t, 2, a.Len())
+}
+
+func TestAccumulatorResolve(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	a1, a2 := async.NewAccumulator(p1), async.NewAccumulator(p2)
+
+	// when
+	a1.Resolve(1)
+	a2.AppendError(errTest)
+	a2.Resolve(2)
+
+	// then
+	if v, err := f1.Try(); assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	_, err2 := f2.Try()
+	assert.ErrorIs(t, err2, errTest)
+}
+
+func TestAccumulatorEmptyFailNow(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	a := async.NewAccumulator(p)
+
+	// when
+	a.FailNow()
+	a.FailNow() // no effect
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, async.ErrFailed)
+}
+
+func TestAccumulatorDeadline(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	a := async.NewAccumulator(p)
+
+	// when
+	a.AppendError(errTest)
+	a.SetDeadline(time.Now().Add(time.Hour))
+	a.SetDeadline(time.Now().Add(time.Millisecond))
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
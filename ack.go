@@ -0,0 +1,57 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"sync/atomic"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Publisher hands msg to a message queue client, which calls report once the broker confirmed or refused delivery.
+// An error returned directly means the message was not accepted for delivery at all.
+type Publisher[M, A any] func(msg M, report func(ack A, err error)) error
+
+// Publish publishes msg and returns a [Future] for its acknowledgement, turning a delivery report callback into a
+// future. Only the first report for a message is considered.
+func Publish[M, A any](publish Publisher[M, A], msg M) Future[A] {
+	p, f := New[A]()
+
+	var reported atomic.Bool
+	report := func(ack A, err error) {
+		if reported.CompareAndSwap(false, true) {
+			p.complete(result.Of(ack, err))
+		}
+	}
+
+	if err := publish(msg, report); err != nil {
+		var zero A
+		report(zero, err)
+	}
+
+	return f
+}
+
+// PublishBatch publishes all messages, returning futures for their acknowledgements in message order.
+func PublishBatch[M, A any](publish Publisher[M, A], msgs []M) []Future[A] {
+	futures := make([]Future[A], len(msgs))
+	for i, msg := range msgs {
+		futures[i] = Publish(publish, msg)
+	}
+
+	return futures
+}
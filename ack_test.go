@@ -0,0 +1,84 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+type ack struct{ offset int }
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var report func(ack, error)
+	publish := func(_ string, r func(ack, error)) error { report = r; return nil }
+
+	// when
+	f := async.Publish(publish, "msg")
+	_, err1 := f.Try()
+	report(ack{offset: 1}, nil)
+	report(ack{offset: 2}, errTest) // ignored
+
+	// then
+	assert.ErrorIs(t, err1, async.ErrNotReady)
+	v, err := f.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v.offset)
+	}
+}
+
+func TestPublishRefused(t *testing.T) {
+	t.Parallel()
+
+	// given
+	publish := func(string, func(ack, error)) error { return errTest }
+
+	// when
+	f := async.Publish(publish, "msg")
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestPublishBatch(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var offset int
+	publish := func(_ string, r func(ack, error)) error {
+		offset++
+		go r(ack{offset: offset}, nil)
+
+		return nil
+	}
+
+	// when
+	futures := async.PublishBatch(publish, []string{"a", "b", "c"})
+	acks, err := async.AwaitAllValues(context.Background(), futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, []ack{{1}, {2}, {3}}, acks)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Outcome is the result of an audited task together with its audit trail.
+type Outcome[R any] struct {
+	Result    result.Result[R]
+	Submitted time.Time // when the task was handed to the runner
+	Started   time.Time // when a worker started running the task, zero if it never ran
+	Finished  time.Time // when the task completed, zero if it never ran
+	Worker    string    // identifier of the worker that ran the task, when the runner is a [WorkerRunner]
+	Attempts  int       // number of attempts recorded with [Audit.Attempt]
+}
+
+// WorkerRunner is implemented by worker pools that can identify the worker running a task.
+type WorkerRunner interface {
+	Runner
+	GoWorker(task func(worker string))
+}
+
+// Audit is passed to audited tasks to record metadata.
+type Audit struct {
+	attempts atomic.Int32
+}
+
+// Attempt records the start of another attempt, returning its number starting with 1.
+func (a *Audit) Attempt() int {
+	return int(a.attempts.Add(1))
+}
+
+// SubmitAudited runs fn on r like [Submit], returning a [Future] of its [Outcome] that records when the task was
+// submitted, started and finished, which worker ran it and how many attempts it made. The future is only rejected
+// when fn panics, with [ErrTaskPanicked], or when r refuses the task, like a [Limiter] with [ErrQueueFull].
+func SubmitAudited[R any](r Runner, fn func(a *Audit) (R, error)) Future[Outcome[R]] {
+	p, f := New[Outcome[R]]()
+	submitted := time.Now()
+
+	task := func(worker string) {
+		completed := false
+		defer func() {
+			if !completed {
+				p.Reject(ErrTaskPanicked)
+			}
+		}()
+
+		var a Audit
+		started := time.Now()
+		value, err := fn(&a)
+		finished := time.Now()
+		completed = true
+
+		p.Resolve(Outcome[R]{
+			Result:    result.Of(value, err),
+			Submitted: submitted,
+			Started:   started,
+			Finished:  finished,
+			Worker:    worker,
+			Attempts:  int(a.attempts.Load()),
+		})
+	}
+
+	if wr, ok := r.(WorkerRunner); ok {
+		wr.GoWorker(task)
+	} else {
+		goOrReject(r, p.tryReject, func() { task("") })
+	}
+
+	return f
+}
+
+// AwaitAllOutcomes waits for all audited futures and returns their outcomes in argument order, for audit trails of
+// batch jobs. Futures that failed or did not complete before the context was canceled get an outcome holding only
+// the error.
+func AwaitAllOutcomes[R any](ctx context.Context, futures ...Future[Outcome[R]]) []Outcome[R] {
+	results := AwaitAllResults(ctx, futures...)
+	outcomes := make([]Outcome[R], len(results))
+	for i, r := range results {
+		if err := r.Err(); err != nil {
+			outcomes[i] = Outcome[R]{Result: result.OfError[R](err)}
+
+			continue
+		}
+		outcomes[i] = r.Value()
+	}
+
+	return outcomes
+}
@@ -0,0 +1,106 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+type workerPool struct {
+	next int
+}
+
+func (w *workerPool) Go(task func()) { task() }
+
+func (w *workerPool) GoWorker(task func(worker string)) {
+	w.next++
+	task("worker-" + strconv.Itoa(w.next))
+}
+
+func TestSubmitAudited(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var r workerPool
+	flaky := func(a *async.Audit) (int, error) {
+		for {
+			if a.Attempt() == 3 {
+				return 3, nil
+			}
+		}
+	}
+
+	// when
+	f1 := async.SubmitAudited(&r, flaky)
+	f2 := async.SubmitAudited(&r, func(*async.Audit) (int, error) { return 0, errTest })
+	outcomes := async.AwaitAllOutcomes(context.Background(), f1, f2)
+
+	// then
+	if assert.Len(t, outcomes, 2) {
+		o1, o2 := outcomes[0], outcomes[1]
+		assert.Equal(t, 3, o1.Result.Value())
+		assert.Equal(t, 3, o1.Attempts)
+		assert.Equal(t, "worker-1", o1.Worker)
+		assert.False(t, o1.Started.Before(o1.Submitted))
+		assert.False(t, o1.Finished.Before(o1.Started))
+		assert.ErrorIs(t, o2.Result.Err(), errTest)
+		assert.Equal(t, "worker-2", o2.Worker)
+	}
+}
+
+func TestSubmitAuditedPlainRunner(t *testing.T) {
+	t.Parallel()
+
+	// given
+	inline := async.RunnerFunc(func(task func()) { task() })
+
+	// when
+	f := async.SubmitAudited(inline, func(*async.Audit) (string, error) { return "ok", nil })
+	o, err := f.Try()
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ok", o.Result.Value())
+		assert.Empty(t, o.Worker)
+		assert.Zero(t, o.Attempts)
+	}
+}
+
+func TestSubmitAuditedQueueFull(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1, async.WithQueue(1, async.RejectOnFull))
+	release := make(chan struct{})
+	blocking := async.Submit(l, func() (int, error) { <-release; return 1, nil })
+	queued := async.Submit(l, func() (int, error) { return 2, nil })
+
+	// when
+	refused := async.SubmitAudited(l, func(*async.Audit) (int, error) { return 3, nil })
+	close(release)
+
+	// then
+	_, err := refused.Await(context.Background())
+	assert.ErrorIs(t, err, async.ErrQueueFull)
+	_, err = async.AwaitAllValues(context.Background(), blocking, queued)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import "fillmore-labs.com/exp/async/result"
+
+// Batch defers the completion of promises until it is flushed, so bulk resolvers like batch RPC response handlers
+// can fulfill many promises in a tight loop without waking awaiters and running callbacks on each step.
+// The zero value is ready to use. A Batch is not safe for concurrent use.
+type Batch struct {
+	_       noCopy
+	pending []deferredCompletion
+}
+
+// deferredCompletion is a completion added to a [Batch].
+type deferredCompletion interface {
+	settle() bool
+	notify()
+}
+
+// Len returns the number of deferred completions.
+func (b *Batch) Len() int {
+	return len(b.pending)
+}
+
+// Flush first completes all deferred promises, then wakes their awaiters and runs their callbacks in the order they
+// were added, so a consumer waking on the first completion already finds the whole batch complete. Completions added
+// to b by callbacks are deferred to the next flush. Flush panics after notifying the others when a promise was
+// already completed, like [Promise.Resolve].
+func (b *Batch) Flush() {
+	pending := b.pending
+	b.pending = nil
+
+	failed := false
+	for i, c := range pending {
+		if !c.settle() {
+			failed = true
+			pending[i] = nil
+		}
+	}
+
+	for _, c := range pending {
+		if c != nil {
+			c.notify()
+		}
+	}
+
+	if failed {
+		panic(errAlreadyCompleted)
+	}
+}
+
+// ResolveIn defers resolving the promise with value until b is flushed.
+func (p Promise[R]) ResolveIn(b *Batch, value R) {
+	b.pending = append(b.pending, &batchCompletion[R]{v: p.value, result: result.OfValue(value)})
+}
+
+// RejectIn defers rejecting the promise with err until b is flushed.
+func (p Promise[R]) RejectIn(b *Batch, err error) {
+	b.pending = append(b.pending, &batchCompletion[R]{v: p.value, result: result.OfError[R](withRejectStack(err))})
+}
+
+// batchCompletion completes v with result in two steps.
+type batchCompletion[R any] struct {
+	v      *value[R]
+	result result.Result[R]
+	head   *callback[R]
+	done   chan struct{}
+}
+
+func (c *batchCompletion[R]) settle() bool {
+	var ok bool
+	c.head, c.done, ok = c.v.settle(c.result)
+
+	return ok
+}
+
+func (c *batchCompletion[R]) notify() {
+	c.v.notify(c.head, c.done)
+}
@@ -0,0 +1,150 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var b async.Batch
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+
+	// when
+	p1.ResolveIn(&b, 1)
+	p2.RejectIn(&b, errTest)
+	doneBefore := f1.IsDone() || f2.IsDone()
+	n := b.Len()
+	b.Flush()
+
+	// then
+	assert.False(t, doneBefore)
+	assert.Equal(t, 2, n)
+	assert.Zero(t, b.Len())
+	if v, err := f1.Try(); assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	_, err := f2.Try()
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestBatchCoalesced(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var b async.Batch
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	p3, f3 := async.New[int]()
+
+	var secondDone, thirdDone bool
+	f1.OnComplete(func(result.Result[int]) {
+		secondDone = f2.IsDone()
+		p3.ResolveIn(&b, 3)
+	})
+
+	// when
+	p1.ResolveIn(&b, 1)
+	p2.ResolveIn(&b, 2)
+	b.Flush()
+	thirdDone = f3.IsDone()
+	n := b.Len()
+	b.Flush()
+
+	// then
+	assert.True(t, secondDone)
+	assert.False(t, thirdDone)
+	assert.Equal(t, 1, n)
+	assert.True(t, f3.IsDone())
+}
+
+func TestBatchAlreadyCompleted(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var b async.Batch
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	p1.Resolve(0)
+
+	// when
+	p1.ResolveIn(&b, 1)
+	p2.ResolveIn(&b, 2)
+	flush := func() { b.Flush() }
+
+	// then
+	assert.Panics(t, flush)
+	if v, err := f1.Try(); assert.NoError(t, err) {
+		assert.Equal(t, 0, v)
+	}
+	if v, err := f2.Try(); assert.NoError(t, err) {
+		assert.Equal(t, 2, v)
+	}
+}
+
+func benchmarkResolve(b *testing.B, resolve func(ps []async.Promise[int])) {
+	b.Helper()
+	const size = 100
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		ps := make([]async.Promise[int], size)
+		var wg sync.WaitGroup
+		wg.Add(size)
+		for j := range ps {
+			var f async.Future[int]
+			ps[j], f = async.New[int]()
+			go func() {
+				defer wg.Done()
+				_, _ = f.Await(ctx)
+			}()
+		}
+		b.StartTimer()
+
+		resolve(ps)
+		wg.Wait()
+	}
+}
+
+func BenchmarkResolveIndividually(b *testing.B) {
+	benchmarkResolve(b, func(ps []async.Promise[int]) {
+		for i, p := range ps {
+			p.Resolve(i)
+		}
+	})
+}
+
+func BenchmarkResolveBatch(b *testing.B) {
+	var batch async.Batch
+	benchmarkResolve(b, func(ps []async.Promise[int]) {
+		for i, p := range ps {
+			p.ResolveIn(&batch, i)
+		}
+		batch.Flush()
+	})
+}
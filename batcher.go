@@ -0,0 +1,130 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrBatchSize is returned when a batch function returns a different number of values than items.
+var ErrBatchSize = errors.New("batch size mismatch")
+
+// Batcher groups individually submitted items into batches for a downstream batch API, in the manner of a
+// DataLoader. A batch is dispatched when it reaches the maximum size or the maximum delay since its first item
+// passed, whichever comes first.
+type Batcher[T, R any] struct {
+	_        noCopy
+	ctx      context.Context //nolint:containedctx
+	fn       func(ctx context.Context, items []T) ([]R, error)
+	maxSize  int
+	maxDelay time.Duration
+
+	mu       sync.Mutex
+	items    []T
+	promises []Promise[R]
+	timer    *time.Timer
+	gen      int // incremented for each dispatched batch, so stale timers are ignored
+}
+
+// NewBatcher creates a [Batcher] calling fn with batches of at most maxSize items, which must return one value per
+// item in the same order. fn runs on its own goroutine with ctx.
+func NewBatcher[T, R any](
+	ctx context.Context, maxSize int, maxDelay time.Duration, fn func(ctx context.Context, items []T) ([]R, error),
+) *Batcher[T, R] {
+	if maxSize < 1 {
+		maxSize = 1
+	}
+
+	return &Batcher[T, R]{ctx: ctx, fn: fn, maxSize: maxSize, maxDelay: maxDelay}
+}
+
+// Submit adds item to the current batch, returning a [Future] for its value.
+func (b *Batcher[T, R]) Submit(item T) Future[R] {
+	p, f := New[R]()
+
+	b.mu.Lock()
+	b.items = append(b.items, item)
+	b.promises = append(b.promises, p)
+	switch n := len(b.items); {
+	case n >= b.maxSize:
+		b.dispatchLocked()
+
+	case n == 1:
+		gen := b.gen
+		b.timer = time.AfterFunc(b.maxDelay, func() { b.flush(gen) })
+	}
+	b.mu.Unlock()
+
+	return f
+}
+
+// Flush dispatches the current batch immediately.
+func (b *Batcher[T, R]) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.items) > 0 {
+		b.dispatchLocked()
+	}
+}
+
+func (b *Batcher[T, R]) flush(gen int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if gen == b.gen {
+		b.dispatchLocked()
+	}
+}
+
+func (b *Batcher[T, R]) dispatchLocked() {
+	items, promises := b.items, b.promises
+	b.items, b.promises = nil, nil
+	b.gen++
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	go b.run(items, promises)
+}
+
+func (b *Batcher[T, R]) run(items []T, promises []Promise[R]) {
+	values, err := b.fn(b.ctx, items)
+	if err == nil && len(values) != len(items) {
+		err = fmt.Errorf("%w: got %d values for %d items", ErrBatchSize, len(values), len(items))
+	}
+
+	if err != nil {
+		r := result.OfError[R](err)
+		for _, p := range promises {
+			p.complete(r)
+		}
+
+		return
+	}
+
+	for i, p := range promises {
+		p.Resolve(values[i])
+	}
+}
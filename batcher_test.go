@@ -0,0 +1,107 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatcher(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var mu sync.Mutex
+	var sizes []int
+	fn := func(_ context.Context, items []int) ([]string, error) {
+		mu.Lock()
+		sizes = append(sizes, len(items))
+		mu.Unlock()
+		values := make([]string, len(items))
+		for i, item := range items {
+			values[i] = strconv.Itoa(item)
+		}
+
+		return values, nil
+	}
+	ctx := context.Background()
+	b := async.NewBatcher(ctx, 3, time.Hour, fn)
+
+	// when
+	var futures []async.Future[string]
+	for i := 0; i < 4; i++ {
+		futures = append(futures, b.Submit(i))
+	}
+	b.Flush()
+
+	// then
+	for i, f := range futures {
+		if v, err := f.Await(ctx); assert.NoError(t, err) {
+			assert.Equal(t, strconv.Itoa(i), v)
+		}
+	}
+	assert.ElementsMatch(t, []int{3, 1}, sizes)
+}
+
+func TestBatcherDelay(t *testing.T) {
+	t.Parallel()
+
+	// given
+	fn := func(_ context.Context, items []int) ([]int, error) { return items, nil }
+	ctx := context.Background()
+	b := async.NewBatcher(ctx, 100, time.Millisecond, fn)
+
+	// when
+	f := b.Submit(7)
+	v, err := f.Await(ctx)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 7, v)
+	}
+}
+
+func TestBatcherErrors(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	failing := async.NewBatcher(ctx, 2, time.Hour, func(context.Context, []int) ([]int, error) {
+		return nil, errTest
+	})
+	short := async.NewBatcher(ctx, 2, time.Hour, func(context.Context, []int) ([]int, error) {
+		return []int{1}, nil
+	})
+
+	// when
+	f1 := failing.Submit(1)
+	_ = failing.Submit(2)
+	f2 := short.Submit(1)
+	_ = short.Submit(2)
+	_, err1 := f1.Await(ctx)
+	_, err2 := f2.Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err1, errTest)
+	assert.ErrorIs(t, err2, async.ErrBatchSize)
+}
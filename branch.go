@@ -0,0 +1,51 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import "fillmore-labs.com/exp/async/result"
+
+// IfThenElse completes with the future returned by then when f resolves to true, or by els when it resolves to false.
+// The branch is chosen in a completion callback, so no goroutine blocks waiting for the decision. When f fails, the
+// result is rejected with its error and neither branch is called.
+func IfThenElse[R any](f Future[bool], then, els func() Future[R]) Future[R] {
+	return IfThenElseFunc(f, func(b bool) bool { return b },
+		func(bool) Future[R] { return then() },
+		func(bool) Future[R] { return els() })
+}
+
+// IfThenElseFunc is like [IfThenElse], but decides with pred on the value of f and passes it to the chosen branch.
+func IfThenElseFunc[T, R any](f Future[T], pred func(T) bool, then, els func(T) Future[R]) Future[R] {
+	p, d := New[R]()
+	dependsOn(d.node(), f.node())
+
+	f.onComplete(func(r result.Result[T]) {
+		value, err := r.V()
+		if err != nil {
+			p.complete(result.OfError[R](err))
+
+			return
+		}
+
+		branch := els
+		if pred(value) {
+			branch = then
+		}
+		p.completeWith(branch(value))
+	})
+
+	return d
+}
@@ -0,0 +1,91 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIfThenElse(t *testing.T) {
+	t.Parallel()
+
+	// given
+	pt, ft := async.New[bool]()
+	pf, ff := async.New[bool]()
+	then := func() async.Future[string] { return async.NewAsync(func() (string, error) { return "then", nil }) }
+	els := func() async.Future[string] { return async.NewAsync(func() (string, error) { return "else", nil }) }
+
+	// when
+	rt := async.IfThenElse(ft, then, els)
+	rf := async.IfThenElse(ff, then, els)
+	pt.Resolve(true)
+	pf.Resolve(false)
+
+	// then
+	ctx := context.Background()
+	if v, err := rt.Await(ctx); assert.NoError(t, err) {
+		assert.Equal(t, "then", v)
+	}
+	if v, err := rf.Await(ctx); assert.NoError(t, err) {
+		assert.Equal(t, "else", v)
+	}
+}
+
+func TestIfThenElseError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[bool]()
+	called := false
+	branch := func() async.Future[int] {
+		called = true
+		_, f := async.New[int]()
+
+		return f
+	}
+
+	// when
+	r := async.IfThenElse(f, branch, branch)
+	p.Reject(errTest)
+
+	// then
+	_, err := r.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, called)
+}
+
+func TestIfThenElseFunc(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	double := func(v int) async.Future[int] { return async.NewAsync(func() (int, error) { return 2 * v, nil }) }
+	negate := func(v int) async.Future[int] { return async.NewAsync(func() (int, error) { return -v, nil }) }
+
+	// when
+	r := async.IfThenElseFunc(f, func(v int) bool { return v > 10 }, double, negate)
+	p.Resolve(3)
+
+	// then
+	if v, err := r.Await(context.Background()); assert.NoError(t, err) {
+		assert.Equal(t, -3, v)
+	}
+}
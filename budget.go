@@ -0,0 +1,109 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBudgetExhausted is wrapped by a [BudgetError].
+var ErrBudgetExhausted = errors.New("latency budget exhausted")
+
+// BudgetError is returned by stages of a chain started after its [Budget] was spent, or running past it.
+type BudgetError struct {
+	Stage  string        // name of the stage
+	Budget time.Duration // total budget of the chain
+}
+
+func (e BudgetError) Error() string {
+	return fmt.Sprintf("stage %q: %v of %v", e.Stage, ErrBudgetExhausted, e.Budget)
+}
+
+func (e BudgetError) Unwrap() error {
+	return ErrBudgetExhausted
+}
+
+// Budget is a total latency budget for a chain of asynchronous stages. Every stage consumes from the same budget
+// instead of coordinating per-stage timeouts by hand.
+type Budget struct {
+	ctx   context.Context //nolint:containedctx // carries the deadline
+	total time.Duration
+}
+
+// NewBudget starts a [Budget] of total from now. Calling cancel releases its resources.
+func NewBudget(ctx context.Context, total time.Duration) (b *Budget, cancel context.CancelFunc) {
+	ctx, cancel = context.WithTimeout(ctx, total)
+
+	return &Budget{ctx: ctx, total: total}, cancel
+}
+
+// Context returns a context ending when the budget is spent, for stages outside of [TransformBudget] and
+// [AndThenBudget] like [Poll].
+func (b *Budget) Context() context.Context {
+	return b.ctx
+}
+
+// Remaining returns the unspent budget, zero when it is exhausted.
+func (b *Budget) Remaining() time.Duration {
+	deadline, _ := b.ctx.Deadline()
+
+	return max(time.Until(deadline), 0)
+}
+
+// TransformBudget is like [TransformNamed], but runs fn only when budget b is not exhausted, passing it a context
+// ending with the budget. Otherwise, or when fn fails because the budget ended, the derived future is rejected with a
+// [BudgetError].
+func TransformBudget[R, S any](
+	b *Budget, stage string, f Future[R], fn func(ctx context.Context, r R, err error) (S, error),
+) Future[S] {
+	return Transform(f, budgeted(b, stage, fn))
+}
+
+// AndThenBudget is like [TransformBudget], but executes fn asynchronously like [AndThen].
+func AndThenBudget[R, S any](
+	b *Budget, stage string, f Future[R], fn func(ctx context.Context, r R, err error) (S, error),
+) Future[S] {
+	return AndThen(f, budgeted(b, stage, fn))
+}
+
+func budgeted[R, S any](
+	b *Budget, stage string, fn func(ctx context.Context, r R, err error) (S, error),
+) func(R, error) (S, error) {
+	return func(r R, err error) (S, error) {
+		if b.ctx.Err() != nil {
+			return *new(S), b.exhausted(stage)
+		}
+
+		s, err := fn(b.ctx, r, err)
+		if err != nil && errors.Is(b.ctx.Err(), context.DeadlineExceeded) {
+			return s, errors.Join(b.exhausted(stage), err)
+		}
+
+		return s, err
+	}
+}
+
+func (b *Budget) exhausted(stage string) error {
+	if errors.Is(b.ctx.Err(), context.DeadlineExceeded) {
+		return BudgetError{Stage: stage, Budget: b.total}
+	}
+
+	return StageError{Stage: stage, Err: context.Cause(b.ctx)}
+}
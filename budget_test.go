@@ -0,0 +1,97 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b, cancel := async.NewBudget(context.Background(), time.Minute)
+	defer cancel()
+	var remaining time.Duration
+
+	// when
+	f := async.TransformBudget(b, "double", resolved(2), func(_ context.Context, v int, err error) (int, error) {
+		remaining = b.Remaining()
+
+		return 2 * v, err
+	})
+
+	// then
+	v, err := f.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, v)
+	}
+	assert.Greater(t, remaining, time.Duration(0))
+	assert.LessOrEqual(t, remaining, time.Minute)
+}
+
+func TestBudgetExhausted(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b, cancel := async.NewBudget(context.Background(), time.Millisecond)
+	defer cancel()
+	<-b.Context().Done()
+	called := false
+
+	// when
+	f := async.AndThenBudget(b, "late", resolved(1), func(context.Context, int, error) (int, error) {
+		called = true
+
+		return 0, nil
+	})
+
+	// then
+	_, err := f.Await(context.Background())
+	var budgetErr async.BudgetError
+	if assert.ErrorAs(t, err, &budgetErr) {
+		assert.Equal(t, "late", budgetErr.Stage)
+		assert.Equal(t, time.Millisecond, budgetErr.Budget)
+	}
+	assert.ErrorIs(t, err, async.ErrBudgetExhausted)
+	assert.False(t, called)
+	assert.Zero(t, b.Remaining())
+}
+
+func TestBudgetSpentDuringStage(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b, cancel := async.NewBudget(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// when
+	f := async.AndThenBudget(b, "slow", resolved(1), func(ctx context.Context, _ int, _ error) (int, error) {
+		<-ctx.Done()
+
+		return 0, ctx.Err()
+	})
+
+	// then
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, async.ErrBudgetExhausted)
+}
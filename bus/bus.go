@@ -0,0 +1,163 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bus provides an in-process typed request/response bus with promise semantics.
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+)
+
+var (
+	// ErrNoHandler is returned when no handler is registered for a request type.
+	ErrNoHandler = errors.New("no handler")
+
+	// ErrTimeout is the cause of requests not answered within the timeout of the bus.
+	ErrTimeout = errors.New("request timed out")
+)
+
+// Bus routes requests to handlers by query and response type.
+type Bus struct {
+	timeout time.Duration
+
+	mu       sync.RWMutex
+	handlers map[any][]*handler
+}
+
+type handler struct {
+	fn any // func(ctx context.Context, q Q) (R, error)
+}
+
+// handlerKey distinguishes handlers by query and response type.
+type handlerKey[Q, R any] struct{}
+
+// New creates a [Bus]. Requests not answered within timeout are rejected with [ErrTimeout], a timeout less or equal
+// to zero means requests are only bounded by their context.
+func New(timeout time.Duration) *Bus {
+	return &Bus{timeout: timeout, handlers: make(map[any][]*handler)}
+}
+
+// Handle registers fn to answer requests of type Q with responses of type R, until unregister is called.
+func Handle[Q, R any](b *Bus, fn func(ctx context.Context, q Q) (R, error)) (unregister func()) {
+	key, h := handlerKey[Q, R]{}, &handler{fn: fn}
+
+	b.mu.Lock()
+	b.handlers[key] = append(b.handlers[key], h)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		hs := b.handlers[key]
+		if idx := slices.Index(hs, h); idx >= 0 {
+			b.handlers[key] = slices.Delete(slices.Clone(hs), idx, idx+1)
+		}
+	}
+}
+
+// Request publishes q to all handlers for Q and R, returning a [Future] completed by the first successful response.
+// The context passed to handlers is canceled once the request is answered. When all handlers fail, the future is
+// rejected with their joined errors; without handlers with [ErrNoHandler].
+func Request[Q, R any](ctx context.Context, b *Bus, q Q) async.Future[R] {
+	b.mu.RLock()
+	hs := b.handlers[handlerKey[Q, R]{}]
+	b.mu.RUnlock()
+
+	p, f := async.New[R]()
+	if len(hs) == 0 {
+		p.Reject(fmt.Errorf("%w for %T -> %T", ErrNoHandler, q, *new(R)))
+
+		return f
+	}
+
+	var cancel context.CancelFunc
+	if b.timeout > 0 {
+		ctx, cancel = context.WithTimeoutCause(ctx, b.timeout, ErrTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	r := &request[R]{ctx: ctx, p: p, cancel: cancel}
+	r.remaining.Store(int32(len(hs)))
+	context.AfterFunc(ctx, r.canceled)
+
+	for _, h := range hs {
+		fn, _ := h.fn.(func(ctx context.Context, q Q) (R, error))
+		go r.handle(func() (R, error) { return fn(ctx, q) })
+	}
+
+	return f
+}
+
+type request[R any] struct {
+	ctx       context.Context //nolint:containedctx
+	p         async.Promise[R]
+	cancel    context.CancelFunc
+	settled   atomic.Bool
+	remaining atomic.Int32
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *request[R]) handle(fn func() (R, error)) {
+	value, err := fn()
+	if err == nil {
+		if r.settled.CompareAndSwap(false, true) {
+			r.cancel()
+			r.p.Resolve(value)
+		}
+
+		return
+	}
+
+	r.mu.Lock()
+	r.errs = append(r.errs, err)
+	r.mu.Unlock()
+
+	if r.remaining.Add(-1) == 0 {
+		if r.ctx.Err() != nil { // handlers likely failed because of the cancellation
+			r.canceled()
+
+			return
+		}
+
+		r.mu.Lock()
+		err := errors.Join(r.errs...)
+		r.mu.Unlock()
+		r.reject(err)
+	}
+}
+
+func (r *request[R]) canceled() {
+	r.reject(async.NewCanceledError(r.ctx, "bus request"))
+}
+
+func (r *request[R]) reject(err error) {
+	if r.settled.CompareAndSwap(false, true) {
+		r.cancel()
+		r.p.Reject(err)
+	}
+}
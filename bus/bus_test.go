@@ -0,0 +1,92 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bus_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async/bus"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var errTest = errors.New("test error")
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+type lookup struct{ id int }
+
+func TestRequest(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := bus.New(0)
+	unregister := bus.Handle(b, func(_ context.Context, q lookup) (string, error) { return strconv.Itoa(q.id), nil })
+	_ = bus.Handle(b, func(context.Context, lookup) (string, error) { return "", errTest })
+	ctx := context.Background()
+
+	// when
+	v, err := bus.Request[lookup, string](ctx, b, lookup{id: 7}).Await(ctx)
+	unregister()
+	_, errFailed := bus.Request[lookup, string](ctx, b, lookup{id: 7}).Await(ctx)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, "7", v)
+	}
+	assert.ErrorIs(t, errFailed, errTest)
+}
+
+func TestNoHandler(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := bus.New(0)
+	_ = bus.Handle(b, func(context.Context, lookup) (int, error) { return 1, nil })
+	ctx := context.Background()
+
+	// when
+	_, err := bus.Request[lookup, string](ctx, b, lookup{}).Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err, bus.ErrNoHandler)
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := bus.New(time.Millisecond)
+	_ = bus.Handle(b, func(ctx context.Context, _ lookup) (string, error) {
+		<-ctx.Done()
+
+		return "", ctx.Err()
+	})
+	ctx := context.Background()
+
+	// when
+	_, err := bus.Request[lookup, string](ctx, b, lookup{}).Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err, bus.ErrTimeout)
+}
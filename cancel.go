@@ -0,0 +1,146 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync/atomic"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// CancelSource signals cancellation to its [CancelToken], independent of context trees.
+type CancelSource struct {
+	v *value[struct{}]
+}
+
+// CancelToken observes the cancellation of a [CancelSource]. The zero value is never canceled.
+type CancelToken struct {
+	v *value[struct{}]
+}
+
+// NewCancelSource creates a new [CancelSource].
+func NewCancelSource() CancelSource {
+	return CancelSource{v: &value[struct{}]{}}
+}
+
+// Cancel cancels all tokens of this source with cause, or [context.Canceled] when cause is nil.
+// Only the first call has an effect.
+func (s CancelSource) Cancel(cause error) {
+	if cause == nil {
+		cause = context.Canceled
+	}
+	_ = s.v.tryComplete(result.OfError[struct{}](cause))
+}
+
+// Token returns a [CancelToken] observing this source.
+func (s CancelSource) Token() CancelToken {
+	return CancelToken(s)
+}
+
+// Done returns a channel that is closed when the token is canceled, or nil for the zero token.
+func (t CancelToken) Done() <-chan struct{} {
+	if t.v == nil {
+		return nil
+	}
+
+	return t.v.doneChan()
+}
+
+// Err returns the cancellation cause, or nil when the token is not canceled.
+func (t CancelToken) Err() error {
+	if t.v == nil || !t.v.completed() {
+		return nil
+	}
+
+	return t.v.v.Err()
+}
+
+// onCancel registers fn to run on cancellation and returns a function deregistering it.
+func (t CancelToken) onCancel(fn func(cause error)) (stop func()) {
+	cb := &callback[struct{}]{fn: func(r result.Result[struct{}]) { fn(r.Err()) }}
+	if !t.v.addCallback(cb) && cb.claim() {
+		cb.fn(t.v.v)
+	}
+
+	return func() { _ = t.v.removeCallback(cb) }
+}
+
+// AnyOf returns a [CancelToken] that is canceled as soon as any of the tokens is.
+func AnyOf(tokens ...CancelToken) CancelToken {
+	s := NewCancelSource()
+	stops := make([]func(), 0, len(tokens))
+	for _, t := range tokens {
+		if t.v != nil {
+			stops = append(stops, t.onCancel(s.Cancel))
+		}
+	}
+	s.stopOnCancel(stops)
+
+	return s.Token()
+}
+
+// AllOf returns a [CancelToken] that is canceled when all tokens are, with the cause of the last one.
+// When a zero token is passed, the result is never canceled.
+func AllOf(tokens ...CancelToken) CancelToken {
+	for _, t := range tokens {
+		if t.v == nil {
+			return CancelToken{}
+		}
+	}
+
+	s := NewCancelSource()
+	var remaining atomic.Int64
+	remaining.Store(int64(len(tokens)))
+	stops := make([]func(), 0, len(tokens))
+	for _, t := range tokens {
+		stops = append(stops, t.onCancel(func(cause error) {
+			if remaining.Add(-1) == 0 {
+				s.Cancel(cause)
+			}
+		}))
+	}
+	s.stopOnCancel(stops)
+
+	return s.Token()
+}
+
+// stopOnCancel calls stops once s is canceled, deregistering a derived token from its parents.
+func (s CancelSource) stopOnCancel(stops []func()) {
+	s.v.onComplete(func(result.Result[struct{}]) {
+		for _, stop := range stops {
+			stop()
+		}
+	})
+}
+
+// WithCancelToken returns a [Future] completing with the result of f, or rejected with the cancellation cause when
+// tok is canceled first.
+func WithCancelToken[R any](f Future[R], tok CancelToken) Future[R] {
+	if tok.v == nil || f.completed() {
+		return f
+	}
+
+	p, d := New[R]()
+	stop := tok.onCancel(func(cause error) { _ = p.tryComplete(result.OfError[R](cause)) })
+	f.onComplete(func(r result.Result[R]) {
+		stop()
+		_ = p.tryComplete(r)
+	})
+
+	return d
+}
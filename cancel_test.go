@@ -0,0 +1,97 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelToken(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s := async.NewCancelSource()
+	tok := s.Token()
+
+	// when
+	before := tok.Err()
+	s.Cancel(errTest)
+	s.Cancel(nil) // no effect
+
+	// then
+	assert.NoError(t, before)
+	<-tok.Done()
+	assert.ErrorIs(t, tok.Err(), errTest)
+}
+
+func TestZeroCancelToken(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var tok async.CancelToken
+
+	// then
+	assert.Nil(t, tok.Done())
+	assert.NoError(t, tok.Err())
+}
+
+func TestAnyOfAllOf(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s1, s2 := async.NewCancelSource(), async.NewCancelSource()
+	anyTok := async.AnyOf(s1.Token(), s2.Token())
+	allTok := async.AllOf(s1.Token(), s2.Token())
+
+	// when
+	s1.Cancel(nil)
+	anyErr, allErr1 := anyTok.Err(), allTok.Err()
+	s2.Cancel(errTest)
+
+	// then
+	assert.ErrorIs(t, anyErr, context.Canceled)
+	assert.NoError(t, allErr1)
+	assert.ErrorIs(t, allTok.Err(), errTest)
+}
+
+func TestWithCancelToken(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	s := async.NewCancelSource()
+
+	// when
+	d1 := async.WithCancelToken(f1, s.Token())
+	d2 := async.WithCancelToken(f2, s.Token())
+	p1.Resolve(1)
+	s.Cancel(errTest)
+	p2.Resolve(2)
+
+	// then
+	v1, err1 := d1.Try()
+	if assert.NoError(t, err1) {
+		assert.Equal(t, 1, v1)
+	}
+	_, err2 := d2.Try()
+	assert.ErrorIs(t, err2, errTest)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+// Checkpoint reports whether the computation should stop early. It returns nil while work should continue and the
+// cancellation cause otherwise. Calling it costs an atomic load, so it can be used inside tight loops.
+type Checkpoint func() error
+
+// Checkpoint returns a [Checkpoint] bound to this token.
+func (t CancelToken) Checkpoint() Checkpoint {
+	if t.v == nil {
+		return func() error { return nil }
+	}
+
+	return func() error {
+		if !t.v.completed() {
+			return nil
+		}
+
+		return t.v.v.Err()
+	}
+}
+
+// NewAsyncCheckpoint is like [NewAsync], but passes fn a [Checkpoint] bound to tok, so CPU-bound producers can
+// honor cancellation without taking a context.
+func NewAsyncCheckpoint[R any](tok CancelToken, fn func(cp Checkpoint) (R, error)) Future[R] {
+	cp := tok.Checkpoint()
+
+	return NewAsync(func() (R, error) { return fn(cp) })
+}
+
+// SubmitCheckpoint is like [Submit], but passes fn a [Checkpoint] bound to tok. Tasks still queued in r when tok is
+// canceled are rejected with the cancellation cause without running fn.
+func SubmitCheckpoint[R any](r Runner, tok CancelToken, fn func(cp Checkpoint) (R, error)) Future[R] {
+	cp := tok.Checkpoint()
+
+	return Submit(r, func() (R, error) {
+		if err := cp(); err != nil {
+			var zero R
+
+			return zero, err
+		}
+
+		return fn(cp)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAsyncCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s := async.NewCancelSource()
+	started := make(chan struct{})
+
+	// when
+	f := async.NewAsyncCheckpoint(s.Token(), func(cp async.Checkpoint) (int, error) {
+		close(started)
+		for i := 0; ; i++ {
+			if err := cp(); err != nil {
+				return i, err
+			}
+		}
+	})
+	<-started
+	s.Cancel(errTest)
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestSubmitCheckpointQueued(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var queued []func()
+	r := async.RunnerFunc(func(task func()) { queued = append(queued, task) })
+	s := async.NewCancelSource()
+	called := false
+
+	// when
+	f := async.SubmitCheckpoint(r, s.Token(), func(_ async.Checkpoint) (int, error) {
+		called = true
+
+		return 1, nil
+	})
+	s.Cancel(nil)
+	for _, task := range queued {
+		task()
+	}
+	_, err := f.Try()
+
+	// then
+	assert.False(t, called)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestZeroTokenCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var tok async.CancelToken
+
+	// then
+	assert.NoError(t, tok.Checkpoint()())
+}
@@ -0,0 +1,138 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrorClass categorizes errors of failed operations, deciding whether to retry, switch to an alternative or give up.
+type ErrorClass int
+
+const (
+	// Transient errors might go away when the operation is repeated.
+	Transient ErrorClass = iota
+	// Permanent errors will not go away by repeating the operation.
+	Permanent
+	// Throttled errors might go away when the operation is repeated after a delay.
+	Throttled
+)
+
+// String returns the name of the error class.
+func (c ErrorClass) String() string {
+	switch c {
+	case Transient:
+		return "transient"
+
+	case Permanent:
+		return "permanent"
+
+	case Throttled:
+		return "throttled"
+
+	default:
+		return "unknown"
+	}
+}
+
+// Classification is the outcome of classifying an error.
+type Classification struct {
+	Class      ErrorClass
+	RetryAfter time.Duration // suggested delay for [Throttled] errors
+}
+
+// ClassifiedError is implemented by errors that know their own [Classification].
+type ClassifiedError interface {
+	error
+	Classification() Classification
+}
+
+// ErrorClassifier decides how a failed operation should be handled.
+type ErrorClassifier func(err error) Classification
+
+var classifier atomic.Pointer[ErrorClassifier]
+
+// SetErrorClassifier registers c as the classifier used by this package. Passing nil restores [DefaultClassifier].
+func SetErrorClassifier(c ErrorClassifier) {
+	if c == nil {
+		classifier.Store(nil)
+
+		return
+	}
+	classifier.Store(&c)
+}
+
+// Classify classifies err with the registered [ErrorClassifier].
+func Classify(err error) Classification {
+	if c := classifier.Load(); c != nil {
+		return (*c)(err)
+	}
+
+	return DefaultClassifier(err)
+}
+
+// DefaultClassifier honors [ClassifiedError] in the error chain, considers context cancellation permanent and
+// everything else transient.
+func DefaultClassifier(err error) Classification {
+	var classified ClassifiedError
+	switch {
+	case errors.As(err, &classified):
+		return classified.Classification()
+
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return Classification{Class: Permanent}
+
+	default:
+		return Classification{Class: Transient}
+	}
+}
+
+// MarkTransient wraps err so that [DefaultClassifier] classifies it as [Transient], stating this explicitly for
+// consumers treating unclassified errors differently.
+func MarkTransient(err error) error {
+	return classifiedError{err: err, classification: Classification{Class: Transient}}
+}
+
+// MarkPermanent wraps err so that [DefaultClassifier] classifies it as [Permanent].
+func MarkPermanent(err error) error {
+	return classifiedError{err: err, classification: Classification{Class: Permanent}}
+}
+
+// MarkThrottled wraps err so that [DefaultClassifier] classifies it as [Throttled] with the given delay.
+func MarkThrottled(err error, retryAfter time.Duration) error {
+	return classifiedError{err: err, classification: Classification{Class: Throttled, RetryAfter: retryAfter}}
+}
+
+type classifiedError struct {
+	err            error
+	classification Classification
+}
+
+func (e classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e classifiedError) Classification() Classification {
+	return e.classification
+}
@@ -0,0 +1,87 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	t.Parallel()
+
+	subTests := []struct {
+		name   string
+		err    error
+		expect async.Classification
+	}{
+		{name: "Plain", err: errTest, expect: async.Classification{Class: async.Transient}},
+		{name: "Canceled", err: context.Canceled, expect: async.Classification{Class: async.Permanent}},
+		{name: "Permanent", err: async.MarkPermanent(errTest), expect: async.Classification{Class: async.Permanent}},
+		{name: "Transient", err: async.MarkTransient(context.Canceled), expect: async.Classification{Class: async.Transient}},
+		{
+			name:   "Throttled",
+			err:    fmt.Errorf("wrapped: %w", async.MarkThrottled(errTest, time.Second)),
+			expect: async.Classification{Class: async.Throttled, RetryAfter: time.Second},
+		},
+	}
+
+	for _, tc := range subTests {
+		err, expect := tc.err, tc.expect
+		_ = t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			c := async.DefaultClassifier(err)
+
+			// then
+			assert.Equal(t, expect, c)
+		})
+	}
+}
+
+func TestMarkedErrorUnwraps(t *testing.T) {
+	t.Parallel()
+
+	// when
+	err := async.MarkPermanent(errTest)
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, errTest.Error(), err.Error())
+	assert.Equal(t, "permanent", async.Permanent.String())
+}
+
+func TestSetErrorClassifier(t *testing.T) { //nolint:paralleltest // modifies global state
+	// given
+	async.SetErrorClassifier(func(error) async.Classification {
+		return async.Classification{Class: async.Throttled, RetryAfter: time.Minute}
+	})
+	defer async.SetErrorClassifier(nil)
+
+	// when
+	c := async.Classify(errTest)
+
+	// then
+	assert.Equal(t, async.Throttled, c.Class)
+	assert.Equal(t, time.Minute, c.RetryAfter)
+}
@@ -17,15 +17,18 @@
 package async
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"fillmore-labs.com/exp/async/result"
 )
 
 // AwaitAll returns a function that yields the results of all futures.
-// If the context is canceled, it returns an error for the remaining futures.
+// Futures that are ready at the same time are yielded in index order, so callers can express a preference by
+// ordering their arguments. If the context is canceled, it returns an error for the remaining futures.
 func AwaitAll[R any](ctx context.Context, futures ...Future[R]) func(yield func(int, result.Result[R]) bool) {
 	i := newIterator(ctx, func(f Future[R]) result.Result[R] { return f.v }, futures)
 
@@ -40,20 +43,122 @@ func AwaitAllAny(ctx context.Context, futures ...AnyFuture) func(yield func(int,
 	return i.yieldTo
 }
 
+// AwaitAllOrdered returns a function that yields the results of all futures in argument order, each as soon as it
+// and all futures before it are complete.
+// If the context is canceled, it returns an error for the remaining futures.
+func AwaitAllOrdered[R any](ctx context.Context, futures ...Future[R]) func(yield func(int, result.Result[R]) bool) {
+	futures = slices.Clone(futures)
+
+	return func(yield func(int, result.Result[R]) bool) {
+		awaitOrdered(ctx, func(f Future[R]) result.Result[R] { return f.v }, futures, yield)
+	}
+}
+
+// AwaitAllOrderedAny returns a function that yields the results of all futures in argument order, each as soon as it
+// and all futures before it are complete.
+// If the context is canceled, it returns an error for the remaining futures.
+func AwaitAllOrderedAny(ctx context.Context, futures ...AnyFuture) func(yield func(int, result.Result[any]) bool) {
+	futures = slices.Clone(futures)
+
+	return func(yield func(int, result.Result[any]) bool) {
+		awaitOrdered(ctx, func(f AnyFuture) result.Result[any] { return f.any() }, futures, yield)
+	}
+}
+
+func awaitOrdered[R any, F AnyFuture](
+	ctx context.Context, value func(f F) result.Result[R], futures []F, yield func(int, result.Result[R]) bool,
+) {
+	for i, f := range futures {
+		if !f.completed() {
+			select {
+			case <-f.Done():
+
+			case <-ctx.Done():
+				e := result.OfError[R](cancelError(ctx, "list yield"))
+				for j := i; j < len(futures) && yield(j, e); j++ {
+				}
+
+				return
+			}
+		}
+
+		if !yield(i, value(f)) {
+			return
+		}
+	}
+}
+
+// Indexed pairs a value with the index of the future it originates from.
+type Indexed[V any] struct {
+	Index int
+	Value V
+}
+
+// AwaitAllTo sends the results of all futures to ch as they complete.
+// If the context is canceled, it returns early with an error.
+func AwaitAllTo[R any](ctx context.Context, ch chan<- Indexed[result.Result[R]], futures ...Future[R]) error {
+	return awaitAllTo(ctx, ch, AwaitAll(ctx, futures...))
+}
+
+// AwaitAllToAny sends the results of all futures to ch as they complete.
+// If the context is canceled, it returns early with an error.
+func AwaitAllToAny(ctx context.Context, ch chan<- Indexed[result.Result[any]], futures ...AnyFuture) error {
+	return awaitAllTo(ctx, ch, AwaitAllAny(ctx, futures...))
+}
+
+func awaitAllTo[R any](
+	ctx context.Context, ch chan<- Indexed[result.Result[R]], iter func(yield func(int, result.Result[R]) bool),
+) error {
+	var yieldErr error
+
+	iter(func(i int, r result.Result[R]) bool {
+		if ctx.Err() == nil {
+			select {
+			case ch <- Indexed[result.Result[R]]{Index: i, Value: r}:
+				return true
+
+			case <-ctx.Done():
+			}
+		}
+		yieldErr = cancelError(ctx, "list AwaitAllTo")
+
+		return false
+	})
+
+	return yieldErr
+}
+
 // AwaitAllResults waits for all futures to complete and returns the results.
 // If the context is canceled, it returns early with errors for the remaining futures.
 func AwaitAllResults[R any](ctx context.Context, futures ...Future[R]) []result.Result[R] {
-	return awaitAllResults(len(futures), AwaitAll(ctx, futures...))
+	return appendAwaitAllResults(nil, len(futures), AwaitAll(ctx, futures...))
 }
 
 // AwaitAllResultsAny waits for all futures to complete and returns the results.
 // If the context is canceled, it returns early with errors for the remaining futures.
 func AwaitAllResultsAny(ctx context.Context, futures ...AnyFuture) []result.Result[any] {
-	return awaitAllResults(len(futures), AwaitAllAny(ctx, futures...))
+	return appendAwaitAllResults(nil, len(futures), AwaitAllAny(ctx, futures...))
 }
 
-func awaitAllResults[R any](n int, iter func(yield func(int, result.Result[R]) bool)) []result.Result[R] {
-	results := make([]result.Result[R], n)
+// AppendAwaitAllResults is like [AwaitAllResults], but appends the results to dst and returns the extended slice.
+func AppendAwaitAllResults[R any](
+	dst []result.Result[R], ctx context.Context, futures ...Future[R], //nolint:revive
+) []result.Result[R] {
+	return appendAwaitAllResults(dst, len(futures), AwaitAll(ctx, futures...))
+}
+
+// AppendAwaitAllResultsAny is like [AwaitAllResultsAny], but appends the results to dst and returns the extended
+// slice.
+func AppendAwaitAllResultsAny(
+	dst []result.Result[any], ctx context.Context, futures ...AnyFuture, //nolint:revive
+) []result.Result[any] {
+	return appendAwaitAllResults(dst, len(futures), AwaitAllAny(ctx, futures...))
+}
+
+func appendAwaitAllResults[R any](
+	dst []result.Result[R], n int, iter func(yield func(int, result.Result[R]) bool),
+) []result.Result[R] {
+	dst, results := grow(dst, n)
 
 	iter(func(i int, r result.Result[R]) bool {
 		results[i] = r
@@ -61,28 +166,56 @@ func awaitAllResults[R any](n int, iter func(yield func(int, result.Result[R]) b
 		return true
 	})
 
-	return results
+	return dst
+}
+
+// Partition splits results into successful values and failures, both carrying the index of their result, so that
+// for example retry logic knows which inputs to replay.
+func Partition[R any](results []result.Result[R]) (values []Indexed[R], errs []AwaitError) {
+	for i, r := range results {
+		if err := r.Err(); err != nil {
+			errs = append(errs, AwaitError{Index: i, Err: err})
+
+			continue
+		}
+		values = append(values, Indexed[R]{Index: i, Value: r.Value()})
+	}
+
+	return values, errs
 }
 
 // AwaitAllValues returns the values of completed futures.
-// If any future fails or the context is canceled, it returns early with an error.
+// If any future fails or the context is canceled, it returns early with an [AwaitError]. The returned slice then
+// holds the values gathered so far, with zero values for futures that did not succeed yet.
 func AwaitAllValues[R any](ctx context.Context, futures ...Future[R]) ([]R, error) {
-	return awaitAllValues(len(futures), AwaitAll(ctx, futures...))
+	return appendAwaitAllValues(nil, len(futures), AwaitAll(ctx, futures...))
 }
 
 // AwaitAllValuesAny returns the values of completed futures.
-// If any future fails or the context is canceled, it returns early with an error.
+// If any future fails or the context is canceled, it returns early with an [AwaitError]. The returned slice then
+// holds the values gathered so far, with nil for futures that did not succeed yet.
 func AwaitAllValuesAny(ctx context.Context, futures ...AnyFuture) ([]any, error) {
-	return awaitAllValues(len(futures), AwaitAllAny(ctx, futures...))
+	return appendAwaitAllValues(nil, len(futures), AwaitAllAny(ctx, futures...))
+}
+
+// AppendAwaitAllValues is like [AwaitAllValues], but appends the values to dst and returns the extended slice.
+func AppendAwaitAllValues[R any](dst []R, ctx context.Context, futures ...Future[R]) ([]R, error) { //nolint:revive
+	return appendAwaitAllValues(dst, len(futures), AwaitAll(ctx, futures...))
 }
 
-func awaitAllValues[R any](n int, iter func(yield func(int, result.Result[R]) bool)) ([]R, error) {
-	results := make([]R, n)
+// AppendAwaitAllValuesAny is like [AwaitAllValuesAny], but appends the values to dst and returns the extended slice.
+func AppendAwaitAllValuesAny(dst []any, ctx context.Context, futures ...AnyFuture) ([]any, error) { //nolint:revive
+	return appendAwaitAllValues(dst, len(futures), AwaitAllAny(ctx, futures...))
+}
+
+func appendAwaitAllValues[R any](dst []R, n int, iter func(yield func(int, result.Result[R]) bool)) ([]R, error) {
+	dst, results := grow(dst, n)
+	clear(results) // a reused buffer might contain stale values
 	var yieldErr error
 
 	iter(func(i int, r result.Result[R]) bool {
 		if r.Err() != nil {
-			yieldErr = fmt.Errorf("list AwaitAllValues result %d: %w", i, r.Err())
+			yieldErr = AwaitError{Index: i, Err: r.Err()}
 
 			return false
 		}
@@ -91,36 +224,332 @@ func awaitAllValues[R any](n int, iter func(yield func(int, result.Result[R]) bo
 		return true
 	})
 
-	return results, yieldErr
+	return dst, yieldErr
+}
+
+// PartialError describes the failed futures of [AwaitAllValuesPartial].
+type PartialError struct {
+	Failed []AwaitError // failures in index order
+}
+
+func (e PartialError) Error() string {
+	if len(e.Failed) == 1 {
+		return e.Failed[0].Error()
+	}
+
+	return fmt.Sprintf("%d async results failed, first: %v", len(e.Failed), e.Failed[0])
+}
+
+// Unwrap returns the [AwaitError] of each failed future.
+func (e PartialError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f
+	}
+
+	return errs
+}
+
+// Indexes returns the indexes of the failed futures.
+func (e PartialError) Indexes() []int {
+	idx := make([]int, len(e.Failed))
+	for i, f := range e.Failed {
+		idx[i] = f.Index
+	}
+
+	return idx
+}
+
+// AwaitAllValuesPartial is like [AwaitAllValues], but waits for all futures instead of stopping at the first failure,
+// so callers can degrade gracefully. It returns the values of the successful futures, with zero values for failed
+// ones, and a [PartialError] when any future failed or the context was canceled before it completed.
+func AwaitAllValuesPartial[R any](ctx context.Context, futures ...Future[R]) ([]R, error) {
+	values := make([]R, len(futures))
+	var failed []AwaitError
+
+	AwaitAll(ctx, futures...)(func(i int, r result.Result[R]) bool {
+		v, err := r.V()
+		if err != nil {
+			failed = append(failed, AwaitError{Index: i, Err: err})
+
+			return true
+		}
+		values[i] = v
+
+		return true
+	})
+
+	if len(failed) == 0 {
+		return values, nil
+	}
+	slices.SortFunc(failed, func(a, b AwaitError) int { return cmp.Compare(a.Index, b.Index) })
+
+	return values, PartialError{Failed: failed}
+}
+
+// ErrTooManyFailures is returned by [AwaitAllTolerant] when more futures failed than tolerated.
+var ErrTooManyFailures = errors.New("too many failures")
+
+// AwaitAllTolerant waits for all futures, tolerating up to k failures. It returns the values, with zero values at
+// failed positions, and the failures as [AwaitError]s in completion order.
+// When the k+1-th future fails, it returns early with an error wrapping [ErrTooManyFailures] and all failures.
+// If the context is canceled, it returns early with an error.
+func AwaitAllTolerant[R any](ctx context.Context, k int, futures ...Future[R]) ([]R, []error, error) {
+	values := make([]R, len(futures))
+	var failures []error
+	canceled := false
+
+	AwaitAll(ctx, futures...)(func(i int, r result.Result[R]) bool {
+		if err := r.Err(); err != nil {
+			if !futures[i].completed() { // yielded because the context is canceled
+				canceled = true
+
+				return false
+			}
+			failures = append(failures, AwaitError{Index: i, Err: err})
+
+			return len(failures) <= k
+		}
+		values[i] = r.Value()
+
+		return true
+	})
+
+	switch {
+	case canceled:
+		return values, failures, cancelError(ctx, "await tolerant")
+
+	case len(failures) > k:
+		return values, failures, fmt.Errorf("%w: %w", ErrTooManyFailures, errors.Join(failures...))
+
+	default:
+		return values, failures, nil
+	}
+}
+
+// grow extends dst by n elements, returning the extended slice and the newly added part.
+func grow[T any](dst []T, n int) ([]T, []T) {
+	l := len(dst)
+	dst = slices.Grow(dst, n)[:l+n]
+
+	return dst, dst[l:]
+}
+
+// AwaitN returns the indexed results of the first n completed futures, regardless of their outcome, for quorum
+// reads and majority voting. Futures ready at the same time are taken in index order, and the remaining futures are
+// released without leaving callbacks behind. It fails with [ErrNoResult] when fewer than n futures are given.
+// If the context is canceled, it returns the results so far together with an error.
+func AwaitN[R any](ctx context.Context, n int, futures ...Future[R]) ([]Indexed[result.Result[R]], error) {
+	if n > len(futures) {
+		return nil, fmt.Errorf("await %d of %d futures: %w", n, len(futures), ErrNoResult)
+	}
+
+	results := make([]Indexed[result.Result[R]], 0, n)
+	if n < 1 {
+		return results, nil
+	}
+
+	var err error
+	AwaitAll(ctx, futures...)(func(i int, r result.Result[R]) bool {
+		if !futures[i].completed() { // yielded because the context is canceled
+			err = cancelError(ctx, "await n")
+
+			return false
+		}
+		results = append(results, Indexed[result.Result[R]]{Index: i, Value: r})
+
+		return len(results) < n
+	})
+
+	return results, err
+}
+
+// AwaitError identifies the future that caused a combinator to fail.
+type AwaitError struct {
+	Index int   // position of the failed future in the argument list
+	Err   error // error of the failed future
+}
+
+func (e AwaitError) Error() string {
+	return fmt.Sprintf("async result %d: %v", e.Index, e.Err)
+}
+
+func (e AwaitError) Unwrap() error {
+	return e.Err
 }
 
 // ErrNoResult is returned when [AwaitFirst] is called on an empty list.
 var ErrNoResult = errors.New("no result")
 
-// AwaitFirst returns the result of the first completed future.
+// AwaitFirst returns the result of the first completed future, preferring the lowest index when several are ready.
 // If the context is canceled, it returns early with an error.
 func AwaitFirst[R any](ctx context.Context, futures ...Future[R]) (R, error) {
 	return awaitFirst(AwaitAll(ctx, futures...))
 }
 
+// AwaitFirstIndexed is like [AwaitFirst], but also returns the index of the future that completed first, for example
+// to record latency per replica. The index is -1 when no future completed.
+func AwaitFirstIndexed[R any](ctx context.Context, futures ...Future[R]) (int, R, error) {
+	return awaitFirstIndexed(AwaitAll(ctx, futures...))
+}
+
+// AwaitFirstReleasing is like [AwaitFirst], but counts as an awaiter of all futures while waiting. When it returns,
+// producers of the losing futures observe the demand drop through [Promise.WatchAwaiters] and can stop their work.
+func AwaitFirstReleasing[R any](ctx context.Context, futures ...Future[R]) (R, error) {
+	for _, f := range futures {
+		f.addAwaiter(1)
+	}
+	defer func() {
+		for _, f := range futures {
+			f.addAwaiter(-1)
+		}
+	}()
+
+	return awaitFirst(AwaitAll(ctx, futures...))
+}
+
 // AwaitFirstAny returns the result of the first completed future.
 // If the context is canceled, it returns early with an error.
 func AwaitFirstAny(ctx context.Context, futures ...AnyFuture) (any, error) {
 	return awaitFirst(AwaitAllAny(ctx, futures...))
 }
 
+// AwaitFirstPrioritized is like [AwaitFirst], but when several futures are ready, it picks the one with the highest
+// priority, ties go to the lower index. priorities[i] is the priority of futures[i], missing priorities are 0.
+func AwaitFirstPrioritized[R any](ctx context.Context, priorities []int, futures ...Future[R]) (R, error) {
+	priority := func(i int) int {
+		if i < len(priorities) {
+			return priorities[i]
+		}
+
+		return 0
+	}
+
+	order := make([]int, len(futures))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(priority(b), priority(a)) })
+
+	sorted := make([]Future[R], len(futures))
+	for i, idx := range order {
+		sorted[i] = futures[idx]
+	}
+
+	return awaitFirst(AwaitAll(ctx, sorted...))
+}
+
+// AwaitFirstSuccess returns the value of the first successful future, skipping rejected ones, which suits racing
+// redundant backends. When all futures fail, it returns the joined errors, each wrapped in an [AwaitError].
+// If the context is canceled, it returns early with an error.
+func AwaitFirstSuccess[R any](ctx context.Context, futures ...Future[R]) (R, error) {
+	if len(futures) == 0 {
+		return *new(R), ErrNoResult
+	}
+
+	var value R
+	var errs []error
+	succeeded, canceled := false, false
+	AwaitAll(ctx, futures...)(func(i int, r result.Result[R]) bool {
+		v, err := r.V()
+		switch {
+		case err == nil:
+			value, succeeded = v, true
+
+			return false
+
+		case !futures[i].completed(): // yielded because the context is canceled
+			canceled = true
+
+			return false
+
+		default:
+			errs = append(errs, AwaitError{Index: i, Err: err})
+
+			return true
+		}
+	})
+
+	switch {
+	case succeeded:
+		return value, nil
+
+	case canceled:
+		return value, cancelError(ctx, "first success")
+
+	default:
+		return value, errors.Join(errs...)
+	}
+}
+
+// ErrNoMatch is returned by [AwaitFirstMatch] when no successful value satisfies the predicate.
+var ErrNoMatch = errors.New("no matching result")
+
+// AwaitFirstMatch returns the first successful value satisfying pred, like the first non-empty search result.
+// Failed futures are skipped. When all futures complete without a match, it returns [ErrNoMatch] joined with the
+// errors of failed futures. Callbacks on futures still pending are released when it returns.
+// If the context is canceled, it returns early with an error.
+func AwaitFirstMatch[R any](ctx context.Context, pred func(R) bool, futures ...Future[R]) (R, error) {
+	var value R
+	errs := []error{ErrNoMatch}
+	matched, canceled := false, false
+	AwaitAll(ctx, futures...)(func(i int, r result.Result[R]) bool {
+		v, err := r.V()
+		switch {
+		case err == nil:
+			if pred(v) {
+				value, matched = v, true
+
+				return false
+			}
+
+			return true
+
+		case !futures[i].completed(): // yielded because the context is canceled
+			canceled = true
+
+			return false
+
+		default:
+			errs = append(errs, AwaitError{Index: i, Err: err})
+
+			return true
+		}
+	})
+
+	switch {
+	case matched:
+		return value, nil
+
+	case canceled:
+		return value, cancelError(ctx, "first match")
+
+	default:
+		return value, errors.Join(errs...)
+	}
+}
+
 func awaitFirst[R any](iter func(yield func(int, result.Result[R]) bool)) (R, error) {
+	_, v, err := awaitFirstIndexed(iter)
+
+	return v, err
+}
+
+func awaitFirstIndexed[R any](iter func(yield func(int, result.Result[R]) bool)) (int, R, error) {
+	idx := -1
 	var v result.Result[R]
 
-	iter(func(_ int, r result.Result[R]) bool {
-		v = r
+	iter(func(i int, r result.Result[R]) bool {
+		idx, v = i, r
 
 		return false
 	})
 
 	if v == nil {
-		return *new(R), ErrNoResult
+		return -1, *new(R), ErrNoResult
 	}
 
-	return v.V()
+	value, err := v.V()
+
+	return idx, value, err
 }
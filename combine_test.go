@@ -18,6 +18,8 @@ package async_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"fillmore-labs.com/exp/async"
@@ -104,6 +106,154 @@ func TestAllValuesError(t *testing.T) {
 	assert.ErrorIs(t, err, errTest)
 }
 
+func TestAppendResults(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	for i := 0; i < iterations; i++ {
+		promises[i].Resolve(i + 1)
+	}
+	buf := make([]result.Result[int], 1, 2*iterations)
+	buf[0] = result.OfValue(0)
+
+	// when
+	ctx := context.Background()
+	results := async.AppendAwaitAllResults(buf, ctx, futures...)
+
+	// then
+	if assert.Len(t, results, iterations+1) {
+		assert.Same(t, &buf[0], &results[0])
+		for i, r := range results {
+			assert.Equal(t, i, r.Value())
+		}
+	}
+}
+
+func TestAppendValuesReuse(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	promises[1].Reject(errTest)
+	promises[2].Resolve(3)
+	buf := []int{7, 7, 7}
+
+	// when
+	ctx := context.Background()
+	values, err := async.AppendAwaitAllValues(buf[:0], ctx, futures...)
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	if assert.Len(t, values, iterations) {
+		assert.NotContains(t, values, 7)
+	}
+}
+
+func TestAllTo(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	for i := 0; i < iterations; i++ {
+		promises[i].Resolve(i + 1)
+	}
+	ch := make(chan async.Indexed[result.Result[int]], iterations)
+
+	// when
+	ctx := context.Background()
+	err := async.AwaitAllTo(ctx, ch, futures...)
+	close(ch)
+
+	// then
+	if assert.NoError(t, err) {
+		var count int
+		for r := range ch {
+			count++
+			assert.Equal(t, r.Index+1, r.Value.Value())
+		}
+		assert.Equal(t, iterations, count)
+	}
+}
+
+func TestAllToCancel(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	ch := make(chan async.Indexed[result.Result[int]]) // nobody receives
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := async.AwaitAllTo(ctx, ch, futures...)
+
+	// then
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestAllValuesPartial(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	promises[1].Reject(errTest)
+
+	// when
+	ctx := context.Background()
+	values, err := async.AwaitAllValues(ctx, futures...)
+
+	// then
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 1, awaitErr.Index)
+		assert.ErrorIs(t, awaitErr, errTest)
+	}
+	assert.Equal(t, []int{1, 0, 0}, values)
+}
+
+func TestAllTolerant(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	promises[1].Reject(errTest)
+	promises[2].Resolve(3)
+
+	// when
+	values, failures, err := async.AwaitAllTolerant(context.Background(), 1, futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{1, 0, 3}, values)
+		if assert.Len(t, failures, 1) {
+			assert.Equal(t, async.AwaitError{Index: 1, Err: errTest}, failures[0])
+		}
+	}
+}
+
+func TestAllTolerantExceeded(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Reject(errTest)
+	promises[1].Reject(errTest)
+
+	// when
+	_, failures, err := async.AwaitAllTolerant(context.Background(), 1, futures...)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrTooManyFailures)
+	assert.ErrorIs(t, err, errTest)
+	assert.Len(t, failures, 2)
+}
+
 func TestFirst(t *testing.T) {
 	t.Parallel()
 
@@ -121,6 +271,111 @@ func TestFirst(t *testing.T) {
 	}
 }
 
+func TestFirstPrefersLowestIndex(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[2].Resolve(3)
+	promises[1].Resolve(2)
+
+	// when
+	ctx := context.Background()
+	v, err := async.AwaitFirst(ctx, futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, v)
+	}
+}
+
+func TestFirstPrioritized(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	promises[2].Resolve(3)
+
+	// when
+	v, err := async.AwaitFirstPrioritized(context.Background(), []int{0, 5, 1}, futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, v)
+	}
+}
+
+func TestAllReadyInIndexOrder(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+
+	// when
+	var order []int
+	async.AwaitAll(context.Background(), futures...)(func(i int, _ result.Result[int]) bool {
+		if i == 0 { // both become ready before the next one is consumed
+			promises[2].Resolve(3)
+			promises[1].Resolve(2)
+		}
+		order = append(order, i)
+
+		return true
+	})
+
+	// then
+	assert.Equal(t, []int{0, 1, 2}, order)
+}
+
+func TestAllOrdered(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[2].Resolve(3)
+	promises[0].Resolve(1)
+
+	// when
+	var order []int
+	async.AwaitAllOrdered(context.Background(), futures...)(func(i int, r result.Result[int]) bool {
+		order = append(order, r.Value())
+		if i == 0 {
+			promises[1].Resolve(2)
+		}
+
+		return true
+	})
+
+	// then
+	assert.Equal(t, []int{1, 2, 3}, order)
+}
+
+func TestAllOrderedCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	promises[2].Resolve(3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	results := make([]result.Result[any], iterations)
+	async.AwaitAllOrderedAny(ctx, futures[0], futures[1], futures[2])(func(i int, r result.Result[any]) bool {
+		results[i] = r
+
+		return true
+	})
+
+	// then
+	assert.Equal(t, 1, results[0].Value())
+	assert.ErrorIs(t, results[1].Err(), context.Canceled)
+	assert.ErrorIs(t, results[2].Err(), context.Canceled)
+}
+
 func TestCombineCancellation(t *testing.T) {
 	t.Parallel()
 
@@ -260,6 +515,34 @@ func TestAwaitAllValuesEmpty(t *testing.T) {
 	}
 }
 
+func TestAwaitAllValuesPartial(t *testing.T) {
+	t.Parallel()
+
+	// given
+	errOther := errors.New("other")
+	futures := make([]async.Future[int], 4)
+	ps := make([]async.Promise[int], 4)
+	for i := range futures {
+		ps[i], futures[i] = async.New[int]()
+	}
+	ps[3].Reject(errOther)
+	ps[0].Resolve(0)
+	ps[1].Reject(errTest)
+	ps[2].Resolve(2)
+
+	// when
+	values, err := async.AwaitAllValuesPartial(context.Background(), futures...)
+
+	// then
+	assert.Equal(t, []int{0, 0, 2, 0}, values)
+	var partial async.PartialError
+	if assert.ErrorAs(t, err, &partial) {
+		assert.Equal(t, []int{1, 3}, partial.Indexes())
+	}
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errOther)
+}
+
 func TestAwaitFirstEmpty(t *testing.T) {
 	t.Parallel()
 
@@ -274,6 +557,206 @@ func TestAwaitFirstEmpty(t *testing.T) {
 	assert.ErrorIs(t, err, async.ErrNoResult)
 }
 
+func TestAwaitFirstIndexed(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f0 := async.New[int]()
+	p1, f1 := async.New[int]()
+	p1.Resolve(1)
+
+	// when
+	idx, v, err := async.AwaitFirstIndexed(context.Background(), f0, f1)
+	idxEmpty, _, errEmpty := async.AwaitFirstIndexed[int](context.Background())
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, idx)
+		assert.Equal(t, 1, v)
+	}
+	assert.Equal(t, -1, idxEmpty)
+	assert.ErrorIs(t, errEmpty, async.ErrNoResult)
+}
+
+func TestAwaitFirstMatch(t *testing.T) {
+	t.Parallel()
+
+	// given
+	nonEmpty := func(s string) bool { return s != "" }
+	_, pending := async.New[string]()
+	futures := []async.Future[string]{resolved(""), rejected[string](errTest), resolved("hit"), pending}
+
+	// when
+	v, err := async.AwaitFirstMatch(context.Background(), nonEmpty, futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, "hit", v)
+	}
+	assert.Zero(t, pending.Stats().Callbacks)
+}
+
+func TestAwaitFirstMatchNone(t *testing.T) {
+	t.Parallel()
+
+	// given
+	nonEmpty := func(s string) bool { return s != "" }
+
+	// when
+	_, err := async.AwaitFirstMatch(context.Background(), nonEmpty, resolved(""), rejected[string](errTest))
+
+	// then
+	assert.ErrorIs(t, err, async.ErrNoMatch)
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestAwaitFirstMatchFailedWhileCanceling(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := endingCtx{context.Background()}
+	p, f := async.New[int]()
+	p.Reject(errTest)
+
+	// when
+	_, err := async.AwaitFirstMatch(ctx, func(int) bool { return true }, f)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrNoMatch)
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestAwaitFirstSuccess(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+
+	// when
+	p1.Reject(errTest)
+	go p2.Resolve(2)
+	v, err := async.AwaitFirstSuccess(ctx, f1, f2)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, v)
+	}
+}
+
+func TestAwaitFirstSuccessAllFailed(t *testing.T) {
+	t.Parallel()
+
+	// given
+	errOther := errors.New("other")
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	p1.Reject(errTest)
+	p2.Reject(errOther)
+
+	// when
+	_, err := async.AwaitFirstSuccess(context.Background(), f1, f2)
+	_, errEmpty := async.AwaitFirstSuccess[int](context.Background())
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errOther)
+	var awaitErr async.AwaitError
+	assert.ErrorAs(t, err, &awaitErr)
+	assert.ErrorIs(t, errEmpty, async.ErrNoResult)
+}
+
+// endingCtx reports an error without closing Done, like a context ending just as a future completes.
+type endingCtx struct{ context.Context }
+
+func (endingCtx) Err() error { return context.Canceled }
+
+func TestAwaitFirstSuccessFailedWhileCanceling(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := endingCtx{context.Background()}
+	p, f := async.New[int]()
+	p.Reject(errTest)
+
+	// when
+	_, err := async.AwaitFirstSuccess(ctx, f)
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.NotErrorIs(t, err, context.Canceled)
+}
+
+func TestAwaitN(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p0, f0 := async.New[int]()
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	p1.Resolve(1)
+	p2.Reject(errTest)
+
+	// when
+	results, err := async.AwaitN(context.Background(), 2, f0, f1, f2)
+	p0.Resolve(0)
+
+	// then
+	if assert.NoError(t, err) && assert.Len(t, results, 2) {
+		assert.Equal(t, 1, results[0].Index)
+		assert.Equal(t, 1, results[0].Value.Value())
+		assert.Equal(t, 2, results[1].Index)
+		assert.ErrorIs(t, results[1].Value.Err(), errTest)
+	}
+	assert.Zero(t, f0.Stats().Callbacks)
+}
+
+func TestAwaitNFailedWhileCanceling(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := endingCtx{context.Background()}
+	p, f := async.New[int]()
+	p.Reject(errTest)
+
+	// when
+	results, err := async.AwaitN(ctx, 1, f)
+
+	// then
+	if assert.NoError(t, err) && assert.Len(t, results, 1) {
+		assert.ErrorIs(t, results[0].Value.Err(), errTest)
+	}
+}
+
+func TestAwaitNReleasesCallbacks(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	p1, f1 := async.New[int]()
+	p1.Resolve(1)
+
+	// when
+	_, err := async.AwaitN(context.Background(), 1, f, f1)
+	stats := f.Stats()
+	p.Resolve(0)
+
+	// then
+	assert.NoError(t, err)
+	assert.Zero(t, stats.Callbacks)
+}
+
+func TestAwaitNTooFew(t *testing.T) {
+	t.Parallel()
+
+	// when
+	_, err := async.AwaitN[int](context.Background(), 1)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrNoResult)
+}
+
 func TestAllAny(t *testing.T) {
 	// given
 	t.Parallel()
@@ -311,3 +794,157 @@ func TestAllAny(t *testing.T) {
 		}
 	}
 }
+
+var fanInSizes = []int{1_000, 10_000, 100_000}
+
+func BenchmarkAwaitAllResults(b *testing.B) {
+	ctx := context.Background()
+
+	for _, n := range fanInSizes {
+		b.Run(fmt.Sprintf("resolved-%d", n), func(b *testing.B) {
+			futures := make([]async.Future[int], n)
+			for i := range futures {
+				p, f := async.New[int]()
+				p.Resolve(i)
+				futures[i] = f
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = async.AwaitAllResults(ctx, futures...)
+			}
+		})
+
+		b.Run(fmt.Sprintf("pending-%d", n), func(b *testing.B) {
+			promises := make([]async.Promise[int], n)
+			futures := make([]async.Future[int], n)
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for j := range futures {
+					promises[j], futures[j] = async.New[int]()
+				}
+				go func() {
+					for j, p := range promises {
+						p.Resolve(j)
+					}
+				}()
+				b.StartTimer()
+
+				_ = async.AwaitAllResults(ctx, futures...)
+			}
+		})
+	}
+}
+
+func BenchmarkAwaitAllResultsAny(b *testing.B) {
+	ctx := context.Background()
+
+	for _, n := range fanInSizes {
+		b.Run(fmt.Sprintf("resolved-%d", n), func(b *testing.B) {
+			futures := make([]async.AnyFuture, n)
+			for i := range futures {
+				p, f := async.New[int]()
+				p.Resolve(i)
+				futures[i] = f
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = async.AwaitAllResultsAny(ctx, futures...)
+			}
+		})
+	}
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	// given
+	results := []result.Result[int]{result.OfValue(1), result.OfError[int](errTest), result.OfValue(3)}
+
+	// when
+	values, errs := async.Partition(results)
+
+	// then
+	assert.Equal(t, []async.Indexed[int]{{Index: 0, Value: 1}, {Index: 2, Value: 3}}, values)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, 1, errs[0].Index)
+		assert.ErrorIs(t, errs[0], errTest)
+	}
+}
+
+func TestAwaitAllResultsLarge(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const count = 100_000 // beyond the 65536 cases of reflect.Select
+	promises := make([]async.Promise[int], count)
+	futures := make([]async.Future[int], count)
+	for i := range futures {
+		promises[i], futures[i] = async.New[int]()
+	}
+
+	// when
+	go func() {
+		for i, p := range promises {
+			p.Resolve(i)
+		}
+	}()
+	results := async.AwaitAllResults(context.Background(), futures...)
+
+	// then
+	if assert.Len(t, results, count) {
+		for i, r := range results {
+			if !assert.Equal(t, i, r.Value()) {
+				break
+			}
+		}
+	}
+}
+
+func TestAwaitFirstReleasing(t *testing.T) {
+	t.Parallel()
+
+	// given
+	winner, fw := async.New[int]()
+	loser, fl := async.New[int]()
+	abandoned := make(chan struct{})
+	stop := loser.WatchAwaiters(func(n int) {
+		if n == 0 {
+			close(abandoned)
+		}
+	})
+	defer stop()
+
+	// when
+	winner.Resolve(1)
+	v, err := async.AwaitFirstReleasing(context.Background(), fw, fl)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	select {
+	case <-abandoned:
+		loser.Reject(context.Canceled)
+	default:
+		t.Error("loser did not observe the demand drop")
+	}
+}
+
+func TestAllTolerantCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, pending := async.New[int]()
+
+	// when
+	_, failures, err := async.AwaitAllTolerant(ctx, 1, pending)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+	assert.Empty(t, failures)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Completer fulfills a [Completion], for operations like flushes, deletes and acknowledgements that produce no value.
+// Like [Promise], it can be fulfilled only once.
+type Completer struct {
+	p Promise[struct{}]
+}
+
+// Completion is a read-only view of an operation that produces no value.
+type Completion struct {
+	f Future[struct{}]
+}
+
+// NewCompletion provides a connected [Completer] and [Completion].
+func NewCompletion() (Completer, Completion) {
+	p, f := New[struct{}]()
+
+	return Completer{p: p}, Completion{f: f}
+}
+
+// NewAsyncCompletion runs fn asynchronously, immediately returning a [Completion] of its outcome.
+func NewAsyncCompletion(fn func() error) Completion {
+	c, f := NewCompletion()
+	go c.Do(fn)
+
+	return f
+}
+
+// Resolve marks the operation as successful.
+func (c Completer) Resolve() {
+	c.p.Resolve(struct{}{})
+}
+
+// Reject marks the operation as failed with err.
+func (c Completer) Reject(err error) {
+	c.p.complete(result.OfError[struct{}](withRejectStack(err)))
+}
+
+// Do runs fn synchronously, fulfilling the [Completer] once it completes.
+func (c Completer) Do(fn func() error) {
+	c.p.complete(result.Of(struct{}{}, withRejectStack(fn())))
+}
+
+// Await blocks until the operation is complete or the context is canceled, returning the error of the operation.
+func (c Completion) Await(ctx context.Context) error {
+	_, err := c.f.Await(ctx)
+
+	return err
+}
+
+// Try returns the error of the operation when complete, [ErrNotReady] otherwise.
+func (c Completion) Try() error {
+	_, err := c.f.Try()
+
+	return err
+}
+
+// OnComplete executes fn with the error of the operation when it is complete.
+func (c Completion) OnComplete(fn func(err error)) {
+	c.f.onSettled(fn)
+}
+
+// Done returns a channel that is closed when the operation is complete.
+func (c Completion) Done() <-chan struct{} {
+	return c.f.Done()
+}
+
+// Future returns the underlying [Future], for use with combinators.
+func (c Completion) Future() Future[struct{}] {
+	return c.f
+}
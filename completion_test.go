@@ -0,0 +1,60 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	c1, f1 := async.NewCompletion()
+	c2, f2 := async.NewCompletion()
+	var settled error
+
+	// when
+	before := f1.Try()
+	f2.OnComplete(func(err error) { settled = err })
+	c1.Resolve()
+	c2.Reject(errTest)
+
+	// then
+	assert.ErrorIs(t, before, async.ErrNotReady)
+	assert.NoError(t, f1.Await(context.Background()))
+	assert.ErrorIs(t, f2.Try(), errTest)
+	assert.ErrorIs(t, settled, errTest)
+}
+
+func TestNewAsyncCompletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	f := async.NewAsyncCompletion(func() error { return errTest })
+
+	// when
+	<-f.Done()
+
+	// then
+	assert.ErrorIs(t, f.Try(), errTest)
+	assert.True(t, f.Future().IsDone())
+}
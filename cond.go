@@ -0,0 +1,124 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Cond is a condition variable delivering futures instead of blocking, for code awaiting conditions like "queue
+// non-empty" or "config loaded".
+type Cond struct {
+	_ noCopy
+
+	// L is held while evaluating predicates, it must guard the state they observe.
+	L sync.Locker
+
+	mu      sync.Mutex
+	waiters map[*condWaiter]struct{}
+}
+
+type condWaiter struct {
+	pred func() bool
+	p    Promise[struct{}]
+}
+
+// NewCond returns a new [Cond] with Locker l.
+func NewCond(l sync.Locker) *Cond {
+	return &Cond{L: l}
+}
+
+// WaitFor returns a [Future] that is resolved once pred returns true, either immediately or on a later
+// [Cond.Broadcast]. When the context ends first, the future is rejected with its cause.
+func (c *Cond) WaitFor(ctx context.Context, pred func() bool) Future[struct{}] {
+	p, f := New[struct{}]()
+
+	// Register before evaluating pred, so a Broadcast after a concurrent state change is not missed.
+	w := &condWaiter{pred: pred, p: p}
+	c.mu.Lock()
+	if c.waiters == nil {
+		c.waiters = make(map[*condWaiter]struct{})
+	}
+	c.waiters[w] = struct{}{}
+	c.mu.Unlock()
+
+	c.L.Lock()
+	ok := pred()
+	c.L.Unlock()
+	if ok {
+		if c.remove(w) {
+			p.Resolve(struct{}{})
+		}
+
+		return f
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		if c.remove(w) {
+			p.complete(result.OfError[struct{}](cancelError(ctx, "cond wait")))
+		}
+	})
+	f.onComplete(func(result.Result[struct{}]) { _ = stop() })
+
+	return f
+}
+
+// Broadcast re-evaluates the predicates of all waiting futures, resolving the satisfied ones. Call it after changing
+// the guarded state, without holding L.
+func (c *Cond) Broadcast() {
+	c.mu.Lock()
+	waiters := make([]*condWaiter, 0, len(c.waiters))
+	for w := range c.waiters {
+		waiters = append(waiters, w)
+	}
+	c.mu.Unlock()
+
+	if len(waiters) == 0 {
+		return
+	}
+
+	c.L.Lock()
+	satisfied := waiters[:0]
+	for _, w := range waiters {
+		if w.pred() {
+			satisfied = append(satisfied, w)
+		}
+	}
+	c.L.Unlock()
+
+	for _, w := range satisfied {
+		if c.remove(w) {
+			w.p.Resolve(struct{}{})
+		}
+	}
+}
+
+// remove deregisters w, returning false when it was already removed.
+func (c *Cond) remove(w *condWaiter) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.waiters[w]; !ok {
+		return false
+	}
+	delete(c.waiters, w)
+
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCond(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var mu sync.Mutex
+	c := async.NewCond(&mu)
+	queue := 0
+	ctx := context.Background()
+
+	// when
+	f1 := c.WaitFor(ctx, func() bool { return queue > 0 })
+	f2 := c.WaitFor(ctx, func() bool { return queue > 1 })
+	mu.Lock()
+	queue++
+	mu.Unlock()
+	c.Broadcast()
+
+	// then
+	assert.True(t, f1.IsDone())
+	assert.False(t, f2.IsDone())
+	assert.True(t, c.WaitFor(ctx, func() bool { return queue == 1 }).IsDone())
+
+	mu.Lock()
+	queue++
+	mu.Unlock()
+	c.Broadcast()
+	_, err := f2.Await(ctx)
+	assert.NoError(t, err)
+}
+
+func TestCondCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var mu sync.Mutex
+	c := async.NewCond(&mu)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// when
+	f := c.WaitFor(ctx, func() bool { return false })
+	cancel()
+	_, err := f.Await(context.Background())
+	c.Broadcast()
+
+	// then
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// hookLocker runs a hook once, right after the first Unlock.
+type hookLocker struct {
+	sync.Mutex
+	hook func()
+}
+
+func (l *hookLocker) Unlock() {
+	l.Mutex.Unlock()
+	if hook := l.hook; hook != nil {
+		l.hook = nil
+		hook()
+	}
+}
+
+func TestCondBroadcastDuringWaitFor(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var l hookLocker
+	c := async.NewCond(&l)
+	ready := false
+	l.hook = func() { // a mutator between the predicate check and the registration of the waiter
+		l.Lock()
+		ready = true
+		l.Unlock()
+		c.Broadcast()
+	}
+
+	// when
+	f := c.WaitFor(context.Background(), func() bool { return ready })
+
+	// then
+	assert.True(t, f.IsDone())
+}
@@ -0,0 +1,81 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrDependencyCycle is returned by futures that would complete with a future depending on themselves, which
+// otherwise deadlocks silently. Cycles are only detected when enabled with [SetCycleDetection].
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+var (
+	detectCycles atomic.Bool
+	trackedEdges atomic.Bool // whether edges were ever recorded, so completions must forget theirs
+	edges        sync.Map    // pending future → futures it waits on
+)
+
+// SetCycleDetection enables or disables a debug mode tracking the dependencies created by [Transform], [AndThen],
+// [Join2] to [Join5], [Flatten], [FlatMap] and [IfThenElse]. A future that would transitively wait on itself is
+// rejected with [ErrDependencyCycle] then. Tracking is expensive, so it is disabled by default.
+func SetCycleDetection(enabled bool) {
+	if enabled {
+		trackedEdges.Store(true)
+	}
+	detectCycles.Store(enabled)
+}
+
+// dependsOn records that the pending future node waits on sources when cycle detection is enabled.
+func dependsOn(node any, sources ...any) {
+	if !detectCycles.Load() {
+		return
+	}
+	edges.Store(node, sources)
+}
+
+// forgetDependencies removes the edges of a completed future, which can no longer be part of a cycle.
+func forgetDependencies(node any) {
+	if trackedEdges.Load() {
+		edges.Delete(node)
+	}
+}
+
+// reaches reports whether target is reachable from node through recorded edges.
+func reaches(node, target any) bool {
+	visited := make(map[any]struct{})
+	stack := []any{node}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == target {
+			return true
+		}
+		if _, ok := visited[n]; ok {
+			continue
+		}
+		visited[n] = struct{}{}
+
+		if sources, ok := edges.Load(n); ok {
+			stack = append(stack, sources.([]any)...) //nolint:forcetypeassert // only slices are stored
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,60 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyCycle(t *testing.T) { //nolint:paralleltest // modifies global state
+	// given
+	async.SetCycleDetection(true)
+	defer async.SetCycleDetection(false)
+	po, outer := async.New[async.Future[int]]()
+	flat := async.Flatten(outer)
+	next := async.Transform(flat, func(v int, err error) (int, error) { return v + 1, err })
+
+	// when
+	po.Resolve(next)
+
+	// then
+	_, err := flat.Try()
+	assert.ErrorIs(t, err, async.ErrDependencyCycle)
+	_, errNext := next.Try()
+	assert.ErrorIs(t, errNext, async.ErrDependencyCycle)
+}
+
+func TestDependencyNoCycle(t *testing.T) { //nolint:paralleltest // modifies global state
+	// given
+	async.SetCycleDetection(true)
+	defer async.SetCycleDetection(false)
+	p, f := async.New[int]()
+	joined := async.Join2(f, async.Transform(f, func(v int, err error) (int, error) { return v * 2, err }))
+	flat := async.FlatMap(f, func(int) async.Future[async.Pair[int, int]] { return joined })
+
+	// when
+	p.Resolve(1)
+
+	// then
+	v, err := flat.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, async.Pair[int, int]{First: 1, Second: 2}, v)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrDraining is returned by [Drainer.Track] once draining has started.
+var ErrDraining = errors.New("drainer is draining")
+
+// Drainer tracks outstanding futures, so they can be awaited on shutdown. The zero value is ready to use.
+type Drainer struct {
+	_           noCopy
+	mu          sync.Mutex
+	draining    bool
+	outstanding int
+	idle        chan struct{}
+}
+
+// Track registers f with the drainer. It returns [ErrDraining] when [Drainer.Drain] has already been called.
+func (d *Drainer) Track(f AnyFuture) error {
+	d.mu.Lock()
+	if d.draining {
+		d.mu.Unlock()
+
+		return ErrDraining
+	}
+	d.outstanding++
+	d.mu.Unlock()
+
+	f.onSettled(func(error) { d.release() })
+
+	return nil
+}
+
+// Outstanding returns the number of tracked futures that are not complete yet.
+func (d *Drainer) Outstanding() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.outstanding
+}
+
+func (d *Drainer) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.outstanding--
+	if d.outstanding == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// Drain stops accepting new futures and waits until all tracked futures are complete or the context is canceled.
+// It can be called multiple times, for example with a fresh deadline after a first attempt timed out.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	if d.outstanding == 0 {
+		d.mu.Unlock()
+
+		return nil
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+
+	case <-ctx.Done():
+		return cancelError(ctx, "drain")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainer(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var d async.Drainer
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[string]()
+	_ = d.Track(f1)
+	_ = d.Track(f2)
+	p1.Resolve(1)
+
+	// when
+	done := make(chan error)
+	go func() { done <- d.Drain(context.Background()) }()
+	p2.Reject(errTest)
+
+	// then
+	assert.NoError(t, <-done)
+	assert.Zero(t, d.Outstanding())
+	assert.ErrorIs(t, d.Track(f1), async.ErrDraining)
+}
+
+func TestDrainerCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var d async.Drainer
+	_, f := async.New[int]()
+	_ = d.Track(f)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := d.Drain(ctx)
+
+	// then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, d.Outstanding())
+}
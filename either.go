@@ -0,0 +1,82 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+)
+
+// Either holds the value of one of two differently typed operations. The zero value holds neither.
+type Either[A, B any] struct {
+	left  A
+	right B
+	side  side
+}
+
+type side uint8
+
+const (
+	sideNone side = iota
+	sideLeft
+	sideRight
+)
+
+// Left returns the value of the first operation and whether it was the one that finished.
+func (e Either[A, _]) Left() (A, bool) {
+	return e.left, e.side == sideLeft
+}
+
+// Right returns the value of the second operation and whether it was the one that finished.
+func (e Either[_, B]) Right() (B, bool) {
+	return e.right, e.side == sideRight
+}
+
+// AwaitEither waits until one of both futures is complete and returns its value together with the information which
+// one it was. When both are ready, fa is preferred. If the finished future failed, its error is returned alongside.
+// If the context is canceled, it returns early with an error.
+func AwaitEither[A, B any](ctx context.Context, fa Future[A], fb Future[B]) (Either[A, B], error) {
+	switch {
+	case fa.completed():
+		return eitherLeft[A, B](fa)
+
+	case fb.completed():
+		return eitherRight[A](fb)
+	}
+
+	select {
+	case <-fa.Done():
+		return eitherLeft[A, B](fa)
+
+	case <-fb.Done():
+		return eitherRight[A](fb)
+
+	case <-ctx.Done():
+		return Either[A, B]{}, cancelError(ctx, "either await")
+	}
+}
+
+func eitherLeft[A, B any](fa Future[A]) (Either[A, B], error) {
+	v, err := fa.v.V()
+
+	return Either[A, B]{left: v, side: sideLeft}, err
+}
+
+func eitherRight[A, B any](fb Future[B]) (Either[A, B], error) {
+	v, err := fb.v.V()
+
+	return Either[A, B]{right: v, side: sideRight}, err
+}
@@ -0,0 +1,84 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitEither(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, fa := async.New[int]()
+	pb, fb := async.New[string]()
+	pb.Resolve("stop")
+
+	// when
+	e, err := async.AwaitEither(context.Background(), fa, fb)
+
+	// then
+	if assert.NoError(t, err) {
+		_, isLeft := e.Left()
+		v, isRight := e.Right()
+		assert.False(t, isLeft)
+		assert.True(t, isRight)
+		assert.Equal(t, "stop", v)
+	}
+}
+
+func TestAwaitEitherPrefersFirst(t *testing.T) {
+	t.Parallel()
+
+	// given
+	pa, fa := async.New[int]()
+	pb, fb := async.New[string]()
+	pb.Resolve("stop")
+	pa.Reject(errTest)
+
+	// when
+	e, err := async.AwaitEither(context.Background(), fa, fb)
+
+	// then
+	_, isLeft := e.Left()
+	assert.True(t, isLeft)
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestAwaitEitherCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, fa := async.New[int]()
+	_, fb := async.New[string]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	e, err := async.AwaitEither(ctx, fa, fb)
+
+	// then
+	assert.ErrorIs(t, err, context.Canceled)
+	_, isLeft := e.Left()
+	_, isRight := e.Right()
+	assert.False(t, isLeft)
+	assert.False(t, isRight)
+}
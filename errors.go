@@ -0,0 +1,72 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrAwaitCanceled is returned together with the context cause when waiting ends because the caller's context
+	// was canceled.
+	ErrAwaitCanceled = errors.New("await canceled")
+
+	// ErrAwaitDeadline is returned together with the context cause when waiting ends because the caller's context
+	// deadline was exceeded.
+	ErrAwaitDeadline = errors.New("await deadline exceeded")
+)
+
+// CanceledError is returned when waiting ends because the caller's context is done. It matches [ErrAwaitCanceled]
+// or [ErrAwaitDeadline] and the cause of the context with [errors.Is].
+type CanceledError struct {
+	Op       string // operation that was waiting
+	Cause    error  // cause of the context cancellation
+	Deadline bool   // whether the context deadline was exceeded
+}
+
+func (e CanceledError) Error() string {
+	return fmt.Sprintf("%s: %v: %v", e.Op, e.sentinel(), e.Cause)
+}
+
+// Unwrap returns [ErrAwaitCanceled] or [ErrAwaitDeadline] followed by the cause.
+func (e CanceledError) Unwrap() []error {
+	return []error{e.sentinel(), e.Cause}
+}
+
+func (e CanceledError) sentinel() error {
+	if e.Deadline {
+		return ErrAwaitDeadline
+	}
+
+	return ErrAwaitCanceled
+}
+
+// NewCanceledError describes the end of waiting in op because ctx is done, for packages building on futures.
+func NewCanceledError(ctx context.Context, op string) CanceledError {
+	return CanceledError{
+		Op:       op,
+		Cause:    context.Cause(ctx),
+		Deadline: errors.Is(ctx.Err(), context.DeadlineExceeded),
+	}
+}
+
+// cancelError describes the end of waiting in op because ctx is done.
+func cancelError(ctx context.Context, op string) error {
+	return NewCanceledError(ctx, op)
+}
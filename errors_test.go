@@ -0,0 +1,100 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitCanceledError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	_, err := f.Await(ctx)
+	_, errSelect := async.Select2(ctx, f, f)
+
+	// then
+	for _, e := range []error{err, errSelect} {
+		assert.ErrorIs(t, e, async.ErrAwaitCanceled)
+		assert.ErrorIs(t, e, context.Canceled)
+		assert.NotErrorIs(t, e, async.ErrAwaitDeadline)
+	}
+}
+
+func TestAwaitDeadlineError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f := async.New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	// when
+	_, err := f.Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitDeadline)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, async.ErrAwaitCanceled)
+}
+
+func TestAwaitCanceledCause(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f := async.New[int]()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errTest)
+
+	// when
+	_, err := f.Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestCanceledError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f := async.New[int]()
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errTest)
+
+	// when
+	_, err := f.Await(ctx)
+
+	// then
+	var canceled async.CanceledError
+	if assert.ErrorAs(t, err, &canceled) {
+		assert.Equal(t, "future await", canceled.Op)
+		assert.Equal(t, errTest, canceled.Cause)
+		assert.False(t, canceled.Deadline)
+	}
+	assert.EqualError(t, err, "future await: await canceled: test error")
+}
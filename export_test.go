@@ -0,0 +1,35 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+// StackedCallbacks returns the physical length of the callback stack of f, including claimed callbacks not yet purged.
+func StackedCallbacks[R any](f Future[R]) int {
+	var n int
+	for cb := f.callbacks.Load(); cb != nil && cb != &f.sealed; cb = cb.next.Load() {
+		n++
+	}
+
+	return n
+}
+
+// DerivedFutures returns the number of shared derived futures registered on f.
+func DerivedFutures[R any](f Future[R]) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.derived)
+}
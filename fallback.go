@@ -0,0 +1,50 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// Fallback returns the value of primary when it succeeds, otherwise the value of the first successful alternate in
+// order, like a primary and secondary datastore. When all fail, it returns their errors joined, each as an
+// [AwaitError] with primary at index 0. A failure classified as [Permanent] by [Classify] ends the chain early, since
+// alternates are not expected to fare better. If the context is canceled, it returns early with an error.
+func Fallback[R any](ctx context.Context, primary Future[R], alternates ...Future[R]) (R, error) {
+	errs := make([]error, 0, 1+len(alternates))
+	for i, f := range append([]Future[R]{primary}, alternates...) {
+		select {
+		case <-f.Done():
+
+		case <-ctx.Done():
+			return *new(R), cancelError(ctx, "fallback")
+		}
+
+		v, err := f.v.V()
+		if err == nil {
+			return v, nil
+		}
+		errs = append(errs, AwaitError{Index: i, Err: err})
+
+		if Classify(err).Class == Permanent {
+			break
+		}
+	}
+
+	return *new(R), errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+
+	// when
+	primary, errPrimary := async.Fallback(ctx, resolved(1), resolved(2))
+	secondary, errSecondary := async.Fallback(ctx, rejected[int](errTest), rejected[int](errTest), resolved(3))
+	_, errAll := async.Fallback(ctx, rejected[int](errTest), rejected[int](errTest))
+
+	// then
+	if assert.NoError(t, errPrimary) {
+		assert.Equal(t, 1, primary)
+	}
+	if assert.NoError(t, errSecondary) {
+		assert.Equal(t, 3, secondary)
+	}
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, errAll, &awaitErr) {
+		assert.Equal(t, 0, awaitErr.Index)
+	}
+	assert.ErrorIs(t, errAll, errTest)
+}
+
+func TestFallbackCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, pending := async.New[int]()
+
+	// when
+	_, err := async.Fallback(ctx, rejected[int](errTest), pending)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+}
+
+func TestFallbackPermanent(t *testing.T) {
+	t.Parallel()
+
+	// when
+	_, err := async.Fallback(context.Background(), rejected[int](async.MarkPermanent(errTest)), resolved(2))
+
+	// then
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 0, awaitErr.Index)
+	}
+	assert.ErrorIs(t, err, errTest)
+}
@@ -19,7 +19,8 @@ package async
 import (
 	"context"
 	"errors"
-	"fmt"
+	"runtime"
+	"time"
 
 	"fillmore-labs.com/exp/async/result"
 )
@@ -32,9 +33,16 @@ type Future[R any] struct {
 	*value[R]
 }
 
+// AnyFuture is a type-erased view of a [Future], for collections of futures with different element types.
 type AnyFuture interface {
+	AwaitAny(ctx context.Context) (any, error)
+	TryAny() (any, error)
 	Done() <-chan struct{}
+	completed() bool
 	any() result.Result[any]
+	notifyIndex(ch chan<- int, idx int) (stop func())
+	onSettled(fn func(err error))
+	node() any
 }
 
 // NewAsync runs fn asynchronously, immediately returning a [Future] that can be used to retrieve the
@@ -48,24 +56,106 @@ func NewAsync[R any](fn func() (R, error)) Future[R] {
 
 // Await returns the cached result or blocks until a result is available or the context is canceled.
 func (f Future[R]) Await(ctx context.Context) (R, error) {
+	if f.completed() {
+		return f.v.V()
+	}
+
+	if ctx.Err() == nil {
+		f.runInline()
+	}
+
+	f.addAwaiter(1)
+	defer f.addAwaiter(-1)
+
 	select { // wait for future completion or context cancel
-	case <-f.done:
+	case <-f.doneChan():
 		return f.v.V()
 
 	case <-ctx.Done():
-		return *new(R), fmt.Errorf("future await: %w", context.Cause(ctx))
+		return *new(R), cancelError(ctx, "future await")
 	}
 }
 
-// Try returns the cached result when ready, [ErrNotReady] otherwise.
-func (f Future[R]) Try() (R, error) {
+// AwaitSpin is like [Future.Await], but first spins for up to spin, yielding the processor between checks of the
+// completion state, before parking. This trades CPU time for latency when completions are expected within
+// microseconds. Use it only when GOMAXPROCS exceeds the number of spinning goroutines.
+func (f Future[R]) AwaitSpin(ctx context.Context, spin time.Duration) (R, error) {
+	const checksPerClockRead = 64
+
+	for deadline := time.Now().Add(spin); ; {
+		for i := 0; i < checksPerClockRead; i++ {
+			if f.completed() {
+				return f.v.V()
+			}
+			runtime.Gosched()
+		}
+		if ctx.Err() != nil || !time.Now().Before(deadline) {
+			break
+		}
+	}
+
+	return f.Await(ctx)
+}
+
+// AwaitFor is like [Future.Await], but waits at most d instead of observing a context. When the future is not
+// complete in time, it returns a [TimeoutError].
+func (f Future[R]) AwaitFor(d time.Duration) (R, error) {
+	if f.completed() {
+		return f.v.V()
+	}
+
+	f.runInline()
+
+	f.addAwaiter(1)
+	defer f.addAwaiter(-1)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
 	select {
-	case <-f.done:
+	case <-f.doneChan():
 		return f.v.V()
 
-	default:
+	case <-timer.C:
+		return *new(R), TimeoutError{Timeout: d}
+	}
+}
+
+// AwaitRaw is like [Future.Await], but returns the cause of a context cancellation verbatim instead of wrapping it,
+// for call sites comparing errors directly.
+func (f Future[R]) AwaitRaw(ctx context.Context) (R, error) {
+	if f.completed() {
+		return f.v.V()
+	}
+
+	if ctx.Err() == nil {
+		f.runInline()
+	}
+
+	f.addAwaiter(1)
+	defer f.addAwaiter(-1)
+
+	select {
+	case <-f.doneChan():
+		return f.v.V()
+
+	case <-ctx.Done():
+		return *new(R), context.Cause(ctx)
+	}
+}
+
+// Try returns the cached result when ready, [ErrNotReady] otherwise.
+func (f Future[R]) Try() (R, error) {
+	if !f.completed() {
 		return *new(R), ErrNotReady
 	}
+
+	return f.v.V()
+}
+
+// IsDone reports whether the future is complete. It is a single atomic load, suitable for polling loops.
+func (f Future[_]) IsDone() bool {
+	return f.completed()
 }
 
 // OnComplete executes fn when the [Future] is fulfilled.
@@ -73,24 +163,145 @@ func (f Future[R]) OnComplete(fn func(r result.Result[R])) {
 	f.onComplete(fn)
 }
 
-func (f Future[R]) ToChannel() <-chan result.Result[R] {
-	ch := make(chan result.Result[R], 1)
-	fn := func(r result.Result[R]) {
-		ch <- r
+// OnCompleteCtx executes fn when the [Future] is fulfilled before the context ends.
+// Once the context is done, fn is deregistered and will never be called.
+func (f Future[R]) OnCompleteCtx(ctx context.Context, fn func(r result.Result[R])) {
+	f.onCompleteCtx(ctx, fn, func() {})
+}
+
+// ChannelOption configures the channels of [Future.ToChannel] and [Future.ToChannelCtx].
+type ChannelOption func(opts *channelOptions)
+
+type channelOptions struct {
+	size int
+}
+
+// WithBufferSize sets the buffer size of the created channel, 1 by default. Negative sizes are treated as 0. With an
+// unbuffered channel the result is sent from a separate goroutine that blocks until it is received.
+func WithBufferSize(size int) ChannelOption {
+	return func(opts *channelOptions) { opts.size = size }
+}
+
+// ToChannel returns a channel that receives the result once the future is complete and is closed afterwards.
+func (f Future[R]) ToChannel(opts ...ChannelOption) <-chan result.Result[R] {
+	ch := newChannel[R](opts)
+	f.onComplete(func(r result.Result[R]) { deliver(ch, r) })
+
+	return ch
+}
+
+// ToChannelCtx is like [Future.ToChannel], but closes the channel without sending a result when the context ends
+// before the future completes.
+func (f Future[R]) ToChannelCtx(ctx context.Context, opts ...ChannelOption) <-chan result.Result[R] {
+	ch := newChannel[R](opts)
+	f.onCompleteCtx(ctx, func(r result.Result[R]) { deliver(ch, r) }, func() { close(ch) })
+
+	return ch
+}
+
+// SendTo sends the result to ch once the future is complete, without closing it, so a channel can be shared by
+// many futures in fan-in consumers. When ch is full, the completing goroutine blocks until the result is received,
+// so results arrive in completion order.
+func (f Future[R]) SendTo(ch chan<- result.Result[R]) {
+	f.onComplete(func(r result.Result[R]) { ch <- r })
+}
+
+func newChannel[R any](opts []ChannelOption) chan result.Result[R] {
+	o := channelOptions{size: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return make(chan result.Result[R], max(o.size, 0))
+}
+
+// deliver sends r to the channel created for a single result and closes it. When the channel is unbuffered, the result
+// is sent from a separate goroutine so the completing goroutine does not block.
+func deliver[R any](ch chan<- result.Result[R], r result.Result[R]) {
+	select {
+	case ch <- r:
 		close(ch)
+
+	default:
+		go func() {
+			ch <- r
+			close(ch)
+		}()
 	}
+}
 
-	f.onComplete(fn)
+// Stats is a snapshot of the internal state of a future for diagnostics.
+type Stats struct {
+	Done      bool // whether the future is complete
+	Awaiters  int  // number of goroutines blocked in Await
+	Callbacks int  // number of completion callbacks waiting to run
+}
 
-	return ch
+// Stats returns diagnostic information, useful to detect convoying on heavily shared futures.
+func (f Future[_]) Stats() Stats {
+	return Stats{
+		Done:      f.completed(),
+		Awaiters:  int(f.awaiters.Load()),
+		Callbacks: f.pendingCallbacks(),
+	}
 }
 
 // Done returns a channel that is closed when the future is complete.
 // It enables the use of future values in select statements.
 func (f Future[_]) Done() <-chan struct{} {
-	return f.done
+	return f.doneChan()
+}
+
+// AwaitAny is like [Future.Await], but returns the value as any.
+func (f Future[R]) AwaitAny(ctx context.Context) (any, error) {
+	if _, err := f.Await(ctx); !f.completed() { // canceled
+		return nil, err
+	}
+
+	return f.any().V()
 }
 
+// TryAny is like [Future.Try], but returns the value as any.
+func (f Future[R]) TryAny() (any, error) {
+	if !f.completed() {
+		return nil, ErrNotReady
+	}
+
+	return f.any().V()
+}
+
+// any returns the result as a Result[any], converting it only once.
 func (f Future[_]) any() result.Result[any] {
-	return f.v.Any()
+	if r := f.erased.Load(); r != nil {
+		return *r
+	}
+
+	r := f.v.Any()
+	if !f.erased.CompareAndSwap(nil, &r) {
+		return *f.erased.Load()
+	}
+
+	return r
+}
+
+// notifyIndex sends idx to ch when the future is complete, until stop is called. ch must have enough buffer space.
+// stop is nil when the future is already complete.
+func (f Future[R]) notifyIndex(ch chan<- int, idx int) (stop func()) {
+	if f.completed() {
+		ch <- idx
+
+		return nil
+	}
+
+	cb := &callback[R]{fn: func(result.Result[R]) { ch <- idx }}
+	if !f.addCallback(cb) && cb.claim() {
+		cb.fn(f.v)
+	}
+
+	return func() { _ = f.removeCallback(cb) }
+}
+
+// onSettled executes fn with the error of the future (nil on success) when it is complete.
+func (f Future[R]) onSettled(fn func(err error)) {
+	f.onComplete(func(r result.Result[R]) { fn(r.Err()) })
 }
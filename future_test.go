@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
 )
@@ -81,6 +82,58 @@ func TestCancellation(t *testing.T) {
 	assert.ErrorIs(t, err, context.Canceled)
 }
 
+func TestAwaitSpin(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx := context.Background()
+
+	// when
+	go p.Resolve(1)
+	v, err := f.AwaitSpin(ctx, time.Millisecond)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestAwaitSpinParks(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f := async.New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// when
+	_, err := f.AwaitSpin(ctx, time.Microsecond)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitDeadline)
+}
+
+func TestAwaitRaw(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	_, err1 := f.AwaitRaw(ctx)
+	p.Resolve(1)
+	v, err2 := f.AwaitRaw(ctx)
+
+	// then
+	assert.Equal(t, context.Canceled, err1) //nolint:testifylint // verbatim is the point
+	if assert.NoError(t, err2) {
+		assert.Equal(t, 1, v)
+	}
+}
+
 func TestMultiple(t *testing.T) {
 	t.Parallel()
 
@@ -137,6 +190,22 @@ func TestTryWait(t *testing.T) {
 	}
 }
 
+func TestIsDone(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	before := f.IsDone()
+	p.Resolve(1)
+	after := f.IsDone()
+
+	// then
+	assert.False(t, before)
+	assert.True(t, after)
+}
+
 func TestMemoizerAllValues(t *testing.T) {
 	t.Parallel()
 
@@ -182,3 +251,196 @@ func TestToChannel(t *testing.T) {
 	}
 	assert.False(t, ok)
 }
+
+func TestToChannelUnbuffered(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	ch := f.ToChannel(async.WithBufferSize(0))
+	p.Resolve(1)
+
+	// then
+	assert.Zero(t, cap(ch))
+	v, err := (<-ch).V()
+	_, ok := <-ch
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.False(t, ok)
+}
+
+func TestToChannelNegativeBuffer(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	ch := f.ToChannel(async.WithBufferSize(-1))
+	p.Resolve(1)
+
+	// then
+	assert.Zero(t, cap(ch))
+	v, err := (<-ch).V()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestSendTo(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const count = 10
+	ch := make(chan result.Result[int], 1)
+	promises := make([]async.Promise[int], count)
+	for i := range promises {
+		var f async.Future[int]
+		promises[i], f = async.New[int]()
+		f.SendTo(ch)
+	}
+
+	// when
+	go func() {
+		for i, p := range promises {
+			p.Resolve(i) // blocks while the channel is full
+		}
+	}()
+
+	// then
+	values := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		values = append(values, (<-ch).Value())
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+}
+
+func TestToChannelCtx(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	ch := f.ToChannelCtx(ctx)
+	p.Resolve(1)
+
+	// then
+	v, err := (<-ch).V()
+	_, ok := <-ch
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.False(t, ok)
+}
+
+func TestToChannelCtxCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// when
+	ch := f.ToChannelCtx(ctx)
+	cancel()
+	_, ok := <-ch
+	p.Resolve(1) // must not send on the closed channel
+
+	// then
+	assert.False(t, ok)
+}
+
+func TestOnCompleteCtx(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var value int
+	f.OnCompleteCtx(ctx, func(r result.Result[int]) { value = r.Value() })
+
+	// when
+	p.Resolve(1)
+
+	// then
+	assert.Equal(t, 1, value)
+}
+
+func TestOnCompleteCtxCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var called bool
+	f.OnCompleteCtx(ctx, func(result.Result[int]) { called = true })
+
+	// when
+	cancel()
+	p.Resolve(1)
+
+	// then
+	assert.False(t, called)
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	f.OnComplete(func(result.Result[int]) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = f.Await(ctx)
+	}()
+
+	// when
+	assert.Eventually(t, func() bool { return f.Stats().Awaiters == 1 }, time.Second, time.Millisecond)
+	before := f.Stats()
+	p.Resolve(1)
+	<-done
+	after := f.Stats()
+
+	// then
+	assert.Equal(t, async.Stats{Done: false, Awaiters: 1, Callbacks: 1}, before)
+	assert.Equal(t, async.Stats{Done: true, Awaiters: 0, Callbacks: 0}, after)
+}
+
+func TestAnyFutureRegistry(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[string]()
+	registry := map[string]async.AnyFuture{"count": f1, "name": f2}
+
+	// when
+	_, err := registry["count"].TryAny()
+	p1.Resolve(1)
+	p2.Reject(errTest)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrNotReady)
+	v1, err1 := registry["count"].AwaitAny(context.Background())
+	if assert.NoError(t, err1) {
+		assert.Equal(t, 1, v1)
+	}
+	v1Again, _ := registry["count"].TryAny()
+	assert.Equal(t, v1, v1Again)
+	_, err2 := registry["name"].AwaitAny(context.Background())
+	assert.ErrorIs(t, err2, errTest)
+}
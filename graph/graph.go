@@ -0,0 +1,132 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package graph executes tasks in dependency order, exposing every task as a typed future.
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrStarted is returned when nodes are added to or a graph is run after it was started.
+var ErrStarted = errors.New("graph already started")
+
+// NodeError identifies the node whose task failed.
+type NodeError struct {
+	Name string
+	Err  error
+}
+
+func (e NodeError) Error() string {
+	return fmt.Sprintf("node %q: %v", e.Name, e.Err)
+}
+
+func (e NodeError) Unwrap() error {
+	return e.Err
+}
+
+// Graph is a builder for tasks with dependencies. Dependencies must be declared before their dependents, so graphs
+// are acyclic by construction.
+type Graph struct {
+	mu      sync.Mutex
+	started bool
+	starts  []func(ctx context.Context)
+	futures []async.AnyFuture
+}
+
+// New creates an empty [Graph].
+func New() *Graph {
+	return &Graph{}
+}
+
+// Node declares a task that runs once all deps succeeded. Values of dependencies are available in fn through their
+// futures. When a dependency fails, the node is rejected with the dependency's error without running fn.
+func Node[R any](g *Graph, name string, fn func(ctx context.Context) (R, error), deps ...async.AnyFuture) async.Future[R] {
+	p, f := async.New[R]()
+	deps = slices.Clone(deps)
+
+	start := func(ctx context.Context) {
+		async.WhenAllSucceed(deps...).OnComplete(func(r result.Result[struct{}]) {
+			if err := r.Err(); err != nil {
+				p.Reject(err)
+
+				return
+			}
+
+			go p.Do(func() (R, error) {
+				v, err := fn(ctx)
+				if err != nil {
+					return v, NodeError{Name: name, Err: err}
+				}
+
+				return v, nil
+			})
+		})
+	}
+
+	if !g.add(f, start) {
+		p.Reject(ErrStarted)
+	}
+
+	return f
+}
+
+func (g *Graph) add(f async.AnyFuture, start func(ctx context.Context)) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.started {
+		return false
+	}
+
+	g.starts = append(g.starts, start)
+	g.futures = append(g.futures, f)
+
+	return true
+}
+
+// Run starts all tasks, each as soon as its dependencies resolve. The returned future resolves when all tasks
+// succeeded, or is rejected with the first failure, in which case the context of the remaining tasks is canceled.
+func (g *Graph) Run(ctx context.Context) async.Future[struct{}] {
+	g.mu.Lock()
+	if g.started {
+		g.mu.Unlock()
+		p, f := async.New[struct{}]()
+		p.Reject(ErrStarted)
+
+		return f
+	}
+	g.started = true
+	starts, futures := g.starts, g.futures
+	g.mu.Unlock()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	for _, start := range starts {
+		start(ctx)
+	}
+
+	done := async.WhenAllSucceed(futures...)
+	done.OnComplete(func(r result.Result[struct{}]) { cancel(r.Err()) })
+
+	return done
+}
@@ -0,0 +1,105 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/graph"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var errTest = errors.New("test error")
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+
+	// given
+	g := graph.New()
+	a := graph.Node(g, "a", func(context.Context) (int, error) { return 2, nil })
+	b := graph.Node(g, "b", func(context.Context) (int, error) { return 3, nil })
+	c := graph.Node(g, "c", func(context.Context) (int, error) {
+		va, _ := a.Try()
+		vb, _ := b.Try()
+
+		return va * vb, nil
+	}, a, b)
+
+	// when
+	done := g.Run(context.Background())
+
+	// then
+	_, err := done.Await(context.Background())
+	if assert.NoError(t, err) {
+		v, err := c.Try()
+		if assert.NoError(t, err) {
+			assert.Equal(t, 6, v)
+		}
+	}
+}
+
+func TestGraphFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	g := graph.New()
+	var ran bool
+	a := graph.Node(g, "a", func(context.Context) (int, error) { return 0, errTest })
+	b := graph.Node(g, "b", func(context.Context) (int, error) { ran = true; return 1, nil }, a)
+
+	// when
+	done := g.Run(context.Background())
+
+	// then
+	_, err := done.Await(context.Background())
+	var nodeErr graph.NodeError
+	if assert.ErrorAs(t, err, &nodeErr) {
+		assert.Equal(t, "a", nodeErr.Name)
+		assert.ErrorIs(t, err, errTest)
+	}
+	_, err = async.WhenAll(a, b).Await(context.Background())
+	assert.NoError(t, err)
+	_, err = b.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, ran)
+}
+
+func TestGraphStarted(t *testing.T) {
+	t.Parallel()
+
+	// given
+	g := graph.New()
+	_, _ = g.Run(context.Background()).Await(context.Background())
+
+	// when
+	late := graph.Node(g, "late", func(context.Context) (int, error) { return 0, nil })
+	again := g.Run(context.Background())
+
+	// then
+	_, err := late.Try()
+	assert.ErrorIs(t, err, graph.ErrStarted)
+	_, err = again.Try()
+	assert.ErrorIs(t, err, graph.ErrStarted)
+}
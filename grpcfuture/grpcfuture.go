@@ -0,0 +1,66 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grpcfuture wraps unary gRPC invocations into futures.
+//
+// Generated gRPC client stubs have the signature
+//
+//	func(ctx context.Context, in *Req, opts ...grpc.CallOption) (*Resp, error)
+//
+// which matches the method parameter of [Call] without this package depending on gRPC. Metadata and trace spans
+// travel in the context, so they are propagated by passing the caller's context to the stub.
+package grpcfuture
+
+import (
+	"context"
+
+	"fillmore-labs.com/exp/async"
+)
+
+// Method is a unary RPC stub, O is the call option type (grpc.CallOption for generated clients).
+type Method[Req, Resp, O any] func(ctx context.Context, req Req, opts ...O) (Resp, error)
+
+// Call invokes method asynchronously and returns a future for the response.
+// The returned cancel function aborts the call when the response is no longer needed, it is safe to call it more
+// than once and after completion.
+func Call[Req, Resp, O any](
+	ctx context.Context, method Method[Req, Resp, O], req Req, opts ...O,
+) (async.Future[Resp], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	f := async.NewAsync(func() (Resp, error) {
+		defer cancel() // release context resources once the call is done
+
+		return method(ctx, req, opts...)
+	})
+
+	return f, cancel
+}
+
+// CallAll invokes method for every request and returns the futures in request order.
+// The returned cancel function aborts all calls still in flight.
+func CallAll[Req, Resp, O any](
+	ctx context.Context, method Method[Req, Resp, O], reqs []Req, opts ...O,
+) ([]async.Future[Resp], context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	futures := make([]async.Future[Resp], len(reqs))
+	for i, req := range reqs {
+		futures[i], _ = Call(ctx, method, req, opts...)
+	}
+
+	return futures, cancel
+}
@@ -0,0 +1,106 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcfuture_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/grpcfuture"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+type callOption struct{ name string }
+
+type ctxKey struct{}
+
+type request struct{ id int }
+
+type response struct{ msg string }
+
+func echo(ctx context.Context, req *request, opts ...callOption) (*response, error) {
+	md, _ := ctx.Value(ctxKey{}).(string)
+	msg := md + ":" + strconv.Itoa(req.id)
+	for _, o := range opts {
+		msg += ":" + o.name
+	}
+
+	return &response{msg: msg}, nil
+}
+
+func blocking(ctx context.Context, _ *request, _ ...callOption) (*response, error) {
+	<-ctx.Done()
+
+	return nil, context.Cause(ctx)
+}
+
+func TestCall(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.WithValue(context.Background(), ctxKey{}, "md")
+
+	// when
+	f, cancel := grpcfuture.Call(ctx, echo, &request{id: 1}, callOption{name: "opt"})
+	defer cancel()
+	resp, err := f.Await(context.Background())
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, "md:1:opt", resp.msg)
+	}
+}
+
+func TestCallCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	f, cancel := grpcfuture.Call(context.Background(), blocking, &request{})
+
+	// when
+	cancel()
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCallAll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	reqs := []*request{{id: 1}, {id: 2}, {id: 3}}
+
+	// when
+	futures, cancel := grpcfuture.CallAll(context.Background(), echo, reqs)
+	defer cancel()
+	values, err := async.AwaitAllValues(context.Background(), futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		for i, v := range values {
+			assert.Equal(t, ":"+strconv.Itoa(i+1), v.msg)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrProducerStalled is returned by futures of a [GuardedPromise] whose producer stopped sending heartbeats.
+var ErrProducerStalled = errors.New("producer stalled")
+
+// GuardedPromise is a [Promise] whose producer must call [GuardedPromise.Heartbeat] at least once per interval until
+// it fulfills the promise, otherwise the future is rejected with [ErrProducerStalled].
+// Unlike [Promise], fulfilling a GuardedPromise after it stalled is ignored.
+type GuardedPromise[R any] struct {
+	*guard[R]
+}
+
+type guard[R any] struct {
+	value    *value[R]
+	interval time.Duration
+	timer    *time.Timer
+}
+
+// NewGuarded creates a [GuardedPromise] requiring heartbeats at least once per interval, and its [Future].
+func NewGuarded[R any](interval time.Duration) (GuardedPromise[R], Future[R]) {
+	v := &value[R]{}
+	g := &guard[R]{value: v, interval: interval}
+	g.timer = time.AfterFunc(interval, func() {
+		_ = v.tryComplete(result.OfError[R](fmt.Errorf("no heartbeat for %v: %w", interval, ErrProducerStalled)))
+	})
+
+	return GuardedPromise[R]{guard: g}, Future[R]{value: v}
+}
+
+// Heartbeat signals that the producer is still making progress.
+func (p GuardedPromise[R]) Heartbeat() {
+	if !p.value.completed() {
+		_ = p.timer.Reset(p.interval)
+	}
+}
+
+// Resolve resolves the promise with a value.
+func (p GuardedPromise[R]) Resolve(value R) {
+	p.complete(result.OfValue(value))
+}
+
+// Reject breaks the promise with an error.
+func (p GuardedPromise[R]) Reject(err error) {
+	p.complete(result.OfError[R](withRejectStack(err)))
+}
+
+// Do runs fn synchronously, fulfilling the promise once it completes.
+// fn should call [GuardedPromise.Heartbeat] while it makes progress.
+func (p GuardedPromise[R]) Do(fn func() (R, error)) {
+	value, err := fn()
+	p.complete(result.Of(value, withRejectStack(err)))
+}
+
+func (p GuardedPromise[R]) complete(r result.Result[R]) {
+	_ = p.timer.Stop()
+	_ = p.value.tryComplete(r)
+}
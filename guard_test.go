@@ -0,0 +1,63 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardedResolve(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.NewGuarded[int](20 * time.Millisecond)
+
+	// when
+	p.Do(func() (int, error) {
+		for i := 0; i < 5; i++ {
+			time.Sleep(5 * time.Millisecond)
+			p.Heartbeat()
+		}
+
+		return 1, nil
+	})
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestGuardedStalled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.NewGuarded[int](time.Millisecond)
+
+	// when
+	_, err := f.Await(context.Background())
+	p.Resolve(1) // ignored, does not panic
+
+	// then
+	assert.ErrorIs(t, err, async.ErrProducerStalled)
+}
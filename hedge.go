@@ -0,0 +1,104 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// HedgePolicy configures [HedgeWithPolicy].
+type HedgePolicy struct {
+	Delay       time.Duration // time after which a further attempt is launched while none completed
+	MaxAttempts int           // maximum number of concurrent attempts, values less than 1 are treated as 1
+}
+
+// Hedge is [HedgeWithPolicy] with at most two attempts, launching the second one after delay.
+func Hedge[R any](ctx context.Context, delay time.Duration, fn func(ctx context.Context) (R, error)) Future[R] {
+	return HedgeWithPolicy(ctx, HedgePolicy{Delay: delay, MaxAttempts: 2}, fn)
+}
+
+// HedgeWithPolicy runs fn and launches speculative attempts whenever none completed within the policy's delay, up to
+// its maximum number of attempts. The returned [Future] completes with the first success or [Permanent] failure, as
+// decided by [Classify], after which the contexts of the slower attempts are canceled. Other failures launch the next
+// attempt right away when no attempt is running, and are returned joined when all attempts failed. This mitigates
+// tail latency at the cost of extra load.
+func HedgeWithPolicy[R any](ctx context.Context, policy HedgePolicy, fn func(ctx context.Context) (R, error)) Future[R] {
+	p, f := New[R]()
+
+	go p.Do(func() (R, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel() // cancel the slower attempts
+
+		return hedge(ctx, policy.Delay, max(policy.MaxAttempts, 1), fn)
+	})
+
+	return f
+}
+
+func hedge[R any](
+	ctx context.Context, delay time.Duration, attempts int, fn func(ctx context.Context) (R, error),
+) (R, error) {
+	ready := make(chan int, attempts)
+	futures := make([]Future[R], 0, attempts)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	launch := func() {
+		f, _ := NewAsyncCtx(ctx, fn)
+		_ = f.notifyIndex(ready, len(futures))
+		futures = append(futures, f)
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(delay)
+	}
+
+	launch()
+	var errs []error
+	for {
+		select {
+		case idx := <-ready:
+			v, err := futures[idx].v.V()
+			if err == nil || Classify(err).Class == Permanent {
+				return v, err
+			}
+
+			errs = append(errs, err)
+			if len(errs) < len(futures) {
+				continue // other attempts are still running
+			}
+			if len(futures) == attempts {
+				return *new(R), errors.Join(errs...)
+			}
+			launch()
+
+		case <-timer.C:
+			if len(futures) < attempts {
+				launch()
+			}
+
+		case <-ctx.Done():
+			return *new(R), cancelError(ctx, "hedge")
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHedge(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var attempts atomic.Int32
+	started, canceled := make(chan struct{}), make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		if attempts.Add(1) == 1 { // the first attempt stalls
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+
+			return 0, ctx.Err()
+		}
+		<-started
+
+		return 2, nil
+	}
+
+	// when
+	f := async.Hedge(context.Background(), time.Millisecond, fn)
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, v)
+	}
+	<-canceled
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestHedgeFast(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var attempts atomic.Int32
+	fn := func(context.Context) (int, error) {
+		attempts.Add(1)
+
+		return 1, nil
+	}
+
+	// when
+	f := async.HedgeWithPolicy(context.Background(), async.HedgePolicy{Delay: time.Hour, MaxAttempts: 3}, fn)
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestHedgeCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+
+		return 0, ctx.Err()
+	}
+
+	// when
+	f := async.HedgeWithPolicy(ctx, async.HedgePolicy{Delay: time.Hour, MaxAttempts: 1}, fn)
+	<-started
+	cancel()
+
+	// then
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestHedgeTransientFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var attempts atomic.Int32
+	fn := func(context.Context) (int, error) {
+		if attempts.Add(1) == 1 { // the first attempt fails fast
+			return 0, errTest
+		}
+
+		return 2, nil
+	}
+
+	// when
+	f := async.Hedge(context.Background(), time.Hour, fn)
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, v)
+	}
+}
+
+func TestHedgePermanentFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var attempts atomic.Int32
+	fn := func(context.Context) (int, error) {
+		attempts.Add(1)
+
+		return 0, async.MarkPermanent(errTest)
+	}
+
+	// when
+	f := async.Hedge(context.Background(), time.Hour, fn)
+
+	// then
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestHedgeAllFailed(t *testing.T) {
+	t.Parallel()
+
+	// given
+	fn := func(context.Context) (int, error) { return 0, errTest }
+
+	// when
+	f := async.HedgeWithPolicy(context.Background(), async.HedgePolicy{Delay: time.Hour, MaxAttempts: 3}, fn)
+
+	// then
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, errTest)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package httpresult writes results of futures as HTTP responses.
+package httpresult
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+)
+
+// StatusCoder is implemented by errors that determine their own HTTP status code. Their messages are considered safe
+// to expose to clients.
+type StatusCoder interface {
+	error
+	StatusCode() int
+}
+
+// StatusCode selects the HTTP status code for err. Errors implementing [StatusCoder] choose their own, await
+// deadlines map to 504, cancellations to 503, and other errors are mapped by their [async.Classify] classification:
+// throttled to 429 and transient to 503, when marked explicitly as an [async.ClassifiedError] like with
+// [async.MarkTransient]. Permanent and unclassified errors map to 500.
+func StatusCode(err error) int {
+	var coder StatusCoder
+	switch {
+	case err == nil:
+		return http.StatusOK
+
+	case errors.As(err, &coder):
+		return coder.StatusCode()
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	}
+
+	var classified async.ClassifiedError
+	switch class := async.Classify(err).Class; {
+	case class == async.Throttled:
+		return http.StatusTooManyRequests
+
+	case class == async.Transient && errors.As(err, &classified):
+		return http.StatusServiceUnavailable
+
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Entry is the JSON representation of a single result.
+type Entry[T any] struct {
+	Value *T     `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Write writes r as a JSON response: the value with status 200 on success, otherwise an error object with the status
+// code selected by [StatusCode]. Throttled errors set the Retry-After header.
+func Write[T any](w http.ResponseWriter, r result.Result[T]) {
+	value, err := r.V()
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, value)
+}
+
+// WriteAll writes a report of all results as a JSON array of [Entry] values, with status 200 when all succeeded and
+// 207 (Multi-Status) otherwise.
+func WriteAll[T any](w http.ResponseWriter, results []result.Result[T]) {
+	status := http.StatusOK
+	entries := make([]Entry[T], len(results))
+	for i, r := range results {
+		value, err := r.V()
+		if err != nil {
+			entries[i].Error = message(err, StatusCode(err))
+			status = http.StatusMultiStatus
+
+			continue
+		}
+		entries[i].Value = &value
+	}
+
+	writeJSON(w, status, entries)
+}
+
+// Await waits for f and writes its result with [Write].
+func Await[T any](ctx context.Context, w http.ResponseWriter, f async.Future[T]) {
+	value, err := f.Await(ctx)
+	Write(w, result.Of(value, err))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code := StatusCode(err)
+	if c := async.Classify(err); c.Class == async.Throttled && c.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(c.RetryAfter.Seconds()))))
+	}
+
+	writeJSON(w, code, Entry[struct{}]{Error: message(err, code)})
+}
+
+// message returns the client visible message for err, hiding internal details unless err is a [StatusCoder].
+func message(err error, code int) string {
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		return coder.Error()
+	}
+
+	return http.StatusText(code)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,121 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httpresult_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/httpresult"
+	"fillmore-labs.com/exp/async/result"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var errTest = errors.New("test error")
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+type notFound struct{}
+
+func (notFound) Error() string   { return "item not found" }
+func (notFound) StatusCode() int { return http.StatusNotFound }
+
+func TestStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"ok", nil, http.StatusOK},
+		{"coder", notFound{}, http.StatusNotFound},
+		{"deadline", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"canceled", context.Canceled, http.StatusServiceUnavailable},
+		{"throttled", async.MarkThrottled(errTest, time.Second), http.StatusTooManyRequests},
+		{"permanent", async.MarkPermanent(errTest), http.StatusInternalServerError},
+		{"transient", async.MarkTransient(errTest), http.StatusServiceUnavailable},
+		{"unclassified", errTest, http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, httpresult.StatusCode(tc.err))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ok, failed, throttled := httptest.NewRecorder(), httptest.NewRecorder(), httptest.NewRecorder()
+
+	// when
+	httpresult.Write(ok, result.OfValue(map[string]int{"a": 1}))
+	httpresult.Write(failed, result.OfError[int](notFound{}))
+	httpresult.Write(throttled, result.OfError[int](async.MarkThrottled(errTest, 1500*time.Millisecond)))
+
+	// then
+	assert.Equal(t, http.StatusOK, ok.Code)
+	assert.JSONEq(t, `{"a":1}`, ok.Body.String())
+	assert.Equal(t, "application/json", ok.Header().Get("Content-Type"))
+	assert.Equal(t, http.StatusNotFound, failed.Code)
+	assert.JSONEq(t, `{"error":"item not found"}`, failed.Body.String())
+	assert.Equal(t, http.StatusTooManyRequests, throttled.Code)
+	assert.Equal(t, "2", throttled.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"Too Many Requests"}`, throttled.Body.String())
+}
+
+func TestWriteAll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	w := httptest.NewRecorder()
+
+	// when
+	httpresult.WriteAll(w, []result.Result[int]{result.OfValue(1), result.OfError[int](errTest)})
+
+	// then
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.JSONEq(t, `[{"value":1},{"error":"Internal Server Error"}]`, w.Body.String())
+}
+
+func TestAwait(t *testing.T) {
+	t.Parallel()
+
+	// given
+	w := httptest.NewRecorder()
+	f := async.NewAsync(func() (string, error) { return "done", nil })
+
+	// when
+	httpresult.Await(context.Background(), w, f)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `"done"`, w.Body.String())
+}
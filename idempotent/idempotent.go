@@ -0,0 +1,147 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package idempotent executes asynchronous operations at most once per idempotency key.
+package idempotent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Store persists outcomes of operations by idempotency key.
+type Store[K comparable, R any] interface {
+	// Load returns the outcome stored for key, ok is false when there is none.
+	Load(ctx context.Context, key K) (r result.Result[R], ok bool, err error)
+	// Save stores the outcome for key.
+	Save(ctx context.Context, key K, r result.Result[R]) error
+}
+
+// Executor runs operations at most once per key, re-submissions attach to the running operation or its stored
+// outcome.
+type Executor[K comparable, R any] struct {
+	store        Store[K, R]
+	onStoreError func(key K, err error)
+
+	mu       sync.Mutex
+	inflight map[K]async.Future[R]
+}
+
+// New creates an [Executor] persisting outcomes in store. onStoreError is called when saving an outcome fails, it
+// may be nil.
+func New[K comparable, R any](store Store[K, R], onStoreError func(key K, err error)) *Executor[K, R] {
+	return &Executor[K, R]{
+		store:        store,
+		onStoreError: onStoreError,
+		inflight:     make(map[K]async.Future[R]),
+	}
+}
+
+// Submit runs fn asynchronously unless an operation with the same key is running or its outcome is stored, in which
+// case the future of that operation or the stored outcome is returned instead.
+// fn runs with the values, but not the cancellation, of ctx, since later submissions attach to it. Only successes and
+// failures classified as [async.Permanent] are stored, so retries with the same key run fn again after transient
+// failures. When the store can't be read, the future is rejected without running fn.
+func (e *Executor[K, R]) Submit(ctx context.Context, key K, fn func(ctx context.Context) (R, error)) async.Future[R] {
+	e.mu.Lock()
+	if f, ok := e.inflight[key]; ok {
+		e.mu.Unlock()
+
+		return f
+	}
+
+	p, f := async.New[R]()
+	e.inflight[key] = f
+	e.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx) // shared by all callers, so the first one must not cancel it
+	go func() {
+		defer func() {
+			e.mu.Lock()
+			delete(e.inflight, key)
+			e.mu.Unlock()
+		}()
+
+		r, ok, err := e.store.Load(ctx, key)
+		switch {
+		case err != nil:
+			r = result.OfError[R](fmt.Errorf("loading idempotency key %v: %w", key, err))
+
+		case !ok:
+			r = result.Of(fn(ctx))
+			if !durable(r.Err()) {
+				break
+			}
+			if err := e.store.Save(ctx, key, r); err != nil && e.onStoreError != nil {
+				e.onStoreError(key, err)
+			}
+		}
+
+		p.Do(r.V)
+	}()
+
+	return f
+}
+
+// durable reports whether the outcome of an operation failing with err should be stored.
+func durable(err error) bool {
+	switch {
+	case err == nil:
+		return true
+
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+
+	default:
+		return async.Classify(err).Class == async.Permanent
+	}
+}
+
+// MemoryStore is an in-memory [Store].
+type MemoryStore[K comparable, R any] struct {
+	mu       sync.Mutex
+	outcomes map[K]result.Result[R]
+}
+
+// NewMemoryStore creates an empty [MemoryStore].
+func NewMemoryStore[K comparable, R any]() *MemoryStore[K, R] {
+	return &MemoryStore[K, R]{outcomes: make(map[K]result.Result[R])}
+}
+
+// Load implements [Store].
+func (s *MemoryStore[K, R]) Load(_ context.Context, key K) (result.Result[R], bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.outcomes[key]
+
+	return r, ok, nil
+}
+
+// Save implements [Store].
+func (s *MemoryStore[K, R]) Save(_ context.Context, key K, r result.Result[R]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outcomes[key] = r
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package idempotent_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/idempotent"
+	"fillmore-labs.com/exp/async/result"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var errTest = errors.New("test error")
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestSubmitOnce(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	e := idempotent.New[string, int](idempotent.NewMemoryStore[string, int](), nil)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(context.Context) (int, error) {
+		<-release
+
+		return int(calls.Add(1)), nil
+	}
+
+	// when
+	f1 := e.Submit(ctx, "order-1", fn)
+	f2 := e.Submit(ctx, "order-1", fn) // reattaches to the running operation
+	close(release)
+	v1, err1 := f1.Await(ctx)
+	_, _ = f2.Await(ctx)
+	v3, err3 := e.Submit(ctx, "order-1", fn).Await(ctx) // served from the store
+
+	// then
+	if assert.NoError(t, err1) && assert.NoError(t, err3) {
+		assert.Equal(t, 1, v1)
+		assert.Equal(t, 1, v3)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+type failingStore struct{}
+
+func (failingStore) Load(context.Context, string) (result.Result[int], bool, error) {
+	return nil, false, errTest
+}
+
+func (failingStore) Save(context.Context, string, result.Result[int]) error {
+	return errTest
+}
+
+func TestStoreFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	e := idempotent.New[string, int](failingStore{}, nil)
+	var called bool
+
+	// when
+	_, err := e.Submit(ctx, "order-1", func(context.Context) (int, error) { called = true; return 1, nil }).Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, called)
+}
+
+func TestTransientFailureNotStored(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	e := idempotent.New[string, int](idempotent.NewMemoryStore[string, int](), nil)
+	var calls atomic.Int32
+	fn := func(context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, errTest
+		}
+
+		return 2, nil
+	}
+
+	// when
+	_, err1 := e.Submit(ctx, "order-1", fn).Await(ctx)
+	v2, err2 := e.Submit(ctx, "order-1", fn).Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err1, errTest)
+	if assert.NoError(t, err2) {
+		assert.Equal(t, 2, v2)
+	}
+}
+
+func TestPermanentFailureStored(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx := context.Background()
+	e := idempotent.New[string, int](idempotent.NewMemoryStore[string, int](), nil)
+	var calls atomic.Int32
+	fn := func(context.Context) (int, error) {
+		calls.Add(1)
+
+		return 0, async.MarkPermanent(errTest)
+	}
+
+	// when
+	_, _ = e.Submit(ctx, "order-1", fn).Await(ctx)
+	_, err := e.Submit(ctx, "order-1", fn).Await(ctx)
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestSubmitterCancellation(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	e := idempotent.New[string, int](idempotent.NewMemoryStore[string, int](), nil)
+	started := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		close(started)
+		<-time.After(10 * time.Millisecond)
+
+		return 1, ctx.Err()
+	}
+
+	// when
+	f := e.Submit(ctx, "order-1", fn)
+	<-started
+	cancel()
+	v, err := f.Await(context.Background())
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
@@ -18,8 +18,6 @@ package async
 
 import (
 	"context"
-	"fmt"
-	"reflect"
 	"runtime/trace"
 
 	"fillmore-labs.com/exp/async/result"
@@ -27,66 +25,129 @@ import (
 
 // This iterator is used to combine the results of multiple asynchronous operations waiting in parallel.
 type iterator[R any, F AnyFuture] struct {
-	_          noCopy
-	numFutures int
-	active     []F
-	cases      []reflect.SelectCase
-	value      func(f F) result.Result[R]
-	ctx        context.Context //nolint:containedctx
+	_       noCopy
+	futures []F
+	value   func(f F) result.Result[R]
+	ctx     context.Context //nolint:containedctx
 }
 
 func newIterator[R any, F AnyFuture](
 	ctx context.Context, value func(f F) result.Result[R], l []F,
 ) *iterator[R, F] {
-	numFutures := len(l)
-	active := make([]F, numFutures)
-	_ = copy(active, l)
-
-	cases := make([]reflect.SelectCase, numFutures+1)
-	for idx, f := range active {
-		cases[idx] = reflect.SelectCase{
-			Dir:  reflect.SelectRecv,
-			Chan: reflect.ValueOf(f.Done()),
-		}
-	}
-	cases[numFutures] = reflect.SelectCase{
-		Dir:  reflect.SelectRecv,
-		Chan: reflect.ValueOf(ctx.Done()),
-	}
+	futures := make([]F, len(l))
+	_ = copy(futures, l)
 
 	return &iterator[R, F]{
-		numFutures: numFutures,
-		active:     active,
-		cases:      cases,
-		value:      value,
-		ctx:        ctx,
+		futures: futures,
+		value:   value,
+		ctx:     ctx,
 	}
 }
 
 func (i *iterator[R, F]) yieldTo(yield func(int, result.Result[R]) bool) {
 	defer trace.StartRegion(i.ctx, "asyncSeq").End()
-	for run := 0; run < i.numFutures; run++ {
-		chosen, _, _ := reflect.Select(i.cases)
+	numFutures := len(i.futures)
+	if numFutures == 0 {
+		return
+	}
+
+	// Completed futures report their index here, the buffer guarantees notifications never block.
+	ready := make(chan int, numFutures)
+	stops := make([]func(), numFutures)
+	for idx, f := range i.futures {
+		stops[idx] = f.notifyIndex(ready, idx)
+	}
+	defer func() { // deregister from futures that were not yielded when stopping early
+		for _, stop := range stops {
+			if stop != nil {
+				stop()
+			}
+		}
+	}()
 
-		if chosen == i.numFutures { // context channel
-			err := fmt.Errorf("list yield canceled: %w", context.Cause(i.ctx))
-			i.yieldErr(yield, err)
+	yielded := make([]bool, numFutures)
+	var pending indexHeap // indexes of ready futures
+	for run := 0; run < numFutures; run++ {
+		if len(pending) == 0 {
+			select {
+			case idx := <-ready:
+				pending.push(idx)
 
+			case <-i.ctx.Done():
+				err := cancelError(i.ctx, "list yield")
+				i.yieldErr(yield, yielded, err)
+
+				return
+			}
+		}
+		pending.drain(ready)
+
+		idx := pending.pop() // prefer the lowest index of all ready futures
+		yielded[idx], stops[idx] = true, nil
+		if !yield(idx, i.value(i.futures[idx])) {
+			return
+		}
+	}
+}
+
+// indexHeap is a min-heap of future indexes.
+type indexHeap []int
+
+// drain adds all indexes currently available in ready.
+func (h *indexHeap) drain(ready <-chan int) {
+	for {
+		select {
+		case idx := <-ready:
+			h.push(idx)
+
+		default:
+			return
+		}
+	}
+}
+
+func (h *indexHeap) push(idx int) {
+	*h = append(*h, idx)
+	q := *h
+	for i := len(q) - 1; i > 0; {
+		parent := (i - 1) / 2
+		if q[parent] <= q[i] {
 			break
 		}
+		q[parent], q[i] = q[i], q[parent]
+		i = parent
+	}
+}
 
-		i.cases[chosen].Chan = reflect.Value{} // Disable case
-		v := i.value(i.active[chosen])
-		if !yield(chosen, v) {
+func (h *indexHeap) pop() int {
+	q := *h
+	n := len(q) - 1
+	idx := q[0]
+	q[0] = q[n]
+	q = q[:n]
+	for i := 0; ; {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < n && q[left] < q[smallest] {
+			smallest = left
+		}
+		if right < n && q[right] < q[smallest] {
+			smallest = right
+		}
+		if smallest == i {
 			break
 		}
+		q[i], q[smallest] = q[smallest], q[i]
+		i = smallest
 	}
+	*h = q
+
+	return idx
 }
 
-func (i *iterator[R, F]) yieldErr(yield func(int, result.Result[R]) bool, err error) {
+func (i *iterator[R, F]) yieldErr(yield func(int, result.Result[R]) bool, yielded []bool, err error) {
 	e := result.OfError[R](err)
-	for idx := 0; idx < i.numFutures; idx++ {
-		if i.cases[idx].Chan.IsValid() && !yield(idx, e) {
+	for idx, done := range yielded {
+		if !done && !yield(idx, e) {
 			break
 		}
 	}
@@ -0,0 +1,202 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"errors"
+	"sync"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrQueueFull is returned by futures of tasks refused or dropped by a [Limiter] with a bounded queue.
+var ErrQueueFull = errors.New("queue full")
+
+// OverflowPolicy decides what a [Limiter] does with a task when its bounded queue is full.
+type OverflowPolicy int
+
+const (
+	// BlockOnFull blocks the submitter until the queue has room.
+	BlockOnFull OverflowPolicy = iota
+	// RejectOnFull refuses the new task.
+	RejectOnFull
+	// DropOldest drops the longest queued task to make room for the new one.
+	DropOldest
+)
+
+// LimiterOption configures a [Limiter].
+type LimiterOption func(l *Limiter)
+
+// WithQueue bounds the number of queued tasks to capacity, applying policy when the queue is full. A capacity less
+// than 1 means no bound, which is the default.
+//
+// Refused or dropped tasks never run. Futures of tasks submitted with [Submit], [SubmitCtx], [SubmitAudited] or
+// [AndThenOn] are rejected with [ErrQueueFull] then, tasks passed directly to [Limiter.Go] are discarded silently.
+func WithQueue(capacity int, policy OverflowPolicy) LimiterOption {
+	return func(l *Limiter) { l.capacity, l.policy = capacity, policy }
+}
+
+// Limiter is a [Runner] executing at most a fixed number of tasks concurrently. Further tasks are queued instead of
+// spawning blocked goroutines, so the completion of a hot future with thousands of continuations does not fork an
+// unbounded burst of goroutines. Panics of tasks are recovered, rejecting the futures of tasks submitted with
+// [Submit] and similar functions with [ErrTaskPanicked], so a panicking task does not take down its worker.
+type Limiter struct {
+	_        noCopy
+	limit    int
+	capacity int
+	policy   OverflowPolicy
+
+	mu      sync.Mutex
+	full    sync.Cond // signaled when a queued task is taken
+	running int
+	queue   []queuedTask
+}
+
+type queuedTask struct {
+	run    func()
+	reject func(err error) // nil for tasks passed to Go
+}
+
+// NewLimiter creates a [Limiter] running at most limit tasks concurrently, a limit less than 1 is treated as 1.
+func NewLimiter(limit int, opts ...LimiterOption) *Limiter {
+	l := &Limiter{limit: max(limit, 1)}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.full.L = &l.mu
+
+	return l
+}
+
+// Go runs task on a new goroutine when below the limit, otherwise queues it for the next free worker.
+func (l *Limiter) Go(task func()) {
+	l.submit(task, nil, true)
+}
+
+// submit runs or queues task, calling reject when it is refused or dropped. With block unset, a [BlockOnFull]
+// limiter queues the task beyond its capacity instead of blocking, for submissions from completion callbacks.
+func (l *Limiter) submit(task func(), reject func(err error), block bool) {
+	var dropped queuedTask
+
+	l.mu.Lock()
+	for {
+		if l.running < l.limit {
+			l.running++
+			l.mu.Unlock()
+
+			go l.work(queuedTask{run: task, reject: reject})
+
+			return
+		}
+
+		if l.capacity < 1 || len(l.queue) < l.capacity || l.policy == BlockOnFull && !block {
+			break
+		}
+
+		switch l.policy {
+		case RejectOnFull:
+			l.mu.Unlock()
+			if reject != nil {
+				reject(ErrQueueFull)
+			}
+
+			return
+
+		case DropOldest:
+			dropped = l.pop()
+
+		default:
+			l.full.Wait()
+		}
+	}
+	l.queue = append(l.queue, queuedTask{run: task, reject: reject})
+	l.mu.Unlock()
+
+	if dropped.reject != nil {
+		dropped.reject(ErrQueueFull)
+	}
+}
+
+// Queued returns the number of tasks waiting for a free worker.
+func (l *Limiter) Queued() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.queue)
+}
+
+func (l *Limiter) work(task queuedTask) {
+	for task.run != nil {
+		task.execute()
+
+		l.mu.Lock()
+		if len(l.queue) > 0 {
+			task = l.pop()
+			l.full.Signal()
+		} else {
+			task = queuedTask{}
+			l.queue = nil
+			l.running--
+		}
+		l.mu.Unlock()
+	}
+}
+
+// execute runs the task, recovering a panic and reporting it with [ErrTaskPanicked].
+func (t queuedTask) execute() {
+	defer func() {
+		if recover() != nil && t.reject != nil {
+			t.reject(ErrTaskPanicked)
+		}
+	}()
+
+	t.run()
+}
+
+// pop removes the longest queued task. The caller must hold l.mu.
+func (l *Limiter) pop() queuedTask {
+	t := l.queue[0]
+	l.queue[0] = queuedTask{}
+	l.queue = l.queue[1:]
+
+	return t
+}
+
+// AndThenOn is like [AndThen], but runs fn on r, for example a shared [Limiter] capping the concurrency of
+// continuations. When fn panics, the derived future is rejected with [ErrTaskPanicked] like with [Submit].
+//
+// fn is submitted from the completion callback of f, so a [Limiter] with a [BlockOnFull] queue exceeds its capacity
+// instead of blocking the goroutine completing f, which might be one of its own workers.
+func AndThenOn[R, S any](f Future[R], r Runner, fn func(R, error) (S, error)) Future[S] {
+	ps, fs := New[S]()
+
+	f.onComplete(func(res result.Result[R]) {
+		enqueueOrReject(r, ps.tryReject, func() {
+			completed := false
+			defer func() {
+				if !completed {
+					ps.Reject(ErrTaskPanicked)
+				}
+			}()
+
+			ps.Do(func() (S, error) { return fn(res.V()) })
+			completed = true
+		})
+	})
+
+	return fs
+}
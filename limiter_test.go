@@ -0,0 +1,184 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const continuations = 100
+	l := async.NewLimiter(3)
+	p, f := async.New[int]()
+	var running, peak atomic.Int32
+	fn := func(v int, err error) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			if old := peak.Load(); n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+
+		return v + 1, err
+	}
+
+	derived := make([]async.Future[int], continuations)
+	for i := range derived {
+		derived[i] = async.AndThenOn(f, l, fn)
+	}
+
+	// when
+	p.Resolve(1)
+	values, err := async.AwaitAllValues(context.Background(), derived...)
+
+	// then
+	if assert.NoError(t, err) {
+		for _, v := range values {
+			assert.Equal(t, 2, v)
+		}
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Zero(t, l.Queued())
+}
+
+func TestLimiterRejectOnFull(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1, async.WithQueue(1, async.RejectOnFull))
+	release := make(chan struct{})
+	blocking := async.Submit(l, func() (int, error) { <-release; return 1, nil })
+	queued := async.Submit(l, func() (int, error) { return 2, nil })
+
+	// when
+	refused := async.Submit(l, func() (int, error) { return 3, nil })
+	close(release)
+
+	// then
+	_, err := refused.Await(context.Background())
+	assert.ErrorIs(t, err, async.ErrQueueFull)
+	values, err := async.AwaitAllValues(context.Background(), blocking, queued)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{1, 2}, values)
+	}
+}
+
+func TestLimiterDropOldest(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1, async.WithQueue(1, async.DropOldest))
+	release := make(chan struct{})
+	_ = async.Submit(l, func() (int, error) { <-release; return 1, nil })
+	oldest := async.Submit(l, func() (int, error) { return 2, nil })
+
+	// when
+	newest := async.Submit(l, func() (int, error) { return 3, nil })
+	close(release)
+
+	// then
+	_, err := oldest.Await(context.Background())
+	assert.ErrorIs(t, err, async.ErrQueueFull)
+	v, err := newest.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, v)
+	}
+}
+
+func TestLimiterBlockOnFull(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1, async.WithQueue(1, async.BlockOnFull))
+	release := make(chan struct{})
+	_ = async.Submit(l, func() (int, error) { <-release; return 1, nil })
+	_ = async.Submit(l, func() (int, error) { return 2, nil })
+
+	// when
+	submitted := make(chan async.Future[int])
+	go func() { submitted <- async.Submit(l, func() (int, error) { return 3, nil }) }()
+
+	// then
+	select {
+	case <-submitted:
+		t.Fatal("submitter not blocked by full queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	v, err := (<-submitted).Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, v)
+	}
+}
+
+func TestLimiterAndThenOnFromWorker(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1, async.WithQueue(1, async.BlockOnFull))
+	p, f := async.New[int]()
+	inc := func(v int, err error) (int, error) { return v + 1, err }
+	derived := []async.Future[int]{async.AndThenOn(f, l, inc), async.AndThenOn(f, l, inc)}
+
+	// when
+	l.Go(func() { p.Resolve(1) }) // the worker completes f while the queue fills up
+
+	// then
+	values, err := async.AwaitAllValues(context.Background(), derived...)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{2, 2}, values)
+	}
+}
+
+func TestLimiterPanic(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1)
+	release := make(chan struct{})
+	blocker := async.Submit(l, func() (int, error) {
+		<-release
+
+		return 0, nil
+	})
+	panicked := async.Submit(l, func() (int, error) { panic("test") })
+	l.Go(func() { panic("test") })
+	after := async.Submit(l, func() (int, error) { return 1, nil })
+
+	// when
+	close(release)
+	_, _ = blocker.Await(context.Background())
+	v, err := after.Await(context.Background())
+
+	// then
+	_, errPanicked := panicked.Await(context.Background())
+	assert.ErrorIs(t, errPanicked, async.ErrTaskPanicked)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.Zero(t, l.Queued())
+}
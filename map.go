@@ -0,0 +1,86 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"fmt"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// AwaitKeyError identifies the future of a map that caused [AwaitAllMap] to fail.
+type AwaitKeyError[K comparable] struct {
+	Key K     // key of the failed future
+	Err error // error of the failed future
+}
+
+func (e AwaitKeyError[K]) Error() string {
+	return fmt.Sprintf("async result %v: %v", e.Key, e.Err)
+}
+
+func (e AwaitKeyError[K]) Unwrap() error {
+	return e.Err
+}
+
+// AwaitAllMap returns the values of a map of futures under their keys, for example fan-outs keyed by shard.
+// If any future fails or the context is canceled, it returns early with an [AwaitKeyError]. The returned map then
+// holds the values gathered so far.
+func AwaitAllMap[K comparable, V any](ctx context.Context, futures map[K]Future[V]) (map[K]V, error) {
+	keys, list := splitMap(futures)
+	values := make(map[K]V, len(futures))
+	var yieldErr error
+
+	AwaitAll(ctx, list...)(func(i int, r result.Result[V]) bool {
+		if r.Err() != nil {
+			yieldErr = AwaitKeyError[K]{Key: keys[i], Err: r.Err()}
+
+			return false
+		}
+		values[keys[i]] = r.Value()
+
+		return true
+	})
+
+	return values, yieldErr
+}
+
+// AwaitAllMapResults waits for all futures of a map to complete and returns the results under their keys.
+// If the context is canceled, it returns early with errors for the remaining futures.
+func AwaitAllMapResults[K comparable, V any](ctx context.Context, futures map[K]Future[V]) map[K]result.Result[V] {
+	keys, list := splitMap(futures)
+	results := make(map[K]result.Result[V], len(futures))
+
+	AwaitAll(ctx, list...)(func(i int, r result.Result[V]) bool {
+		results[keys[i]] = r
+
+		return true
+	})
+
+	return results
+}
+
+func splitMap[K comparable, V any](futures map[K]Future[V]) ([]K, []Future[V]) {
+	keys := make([]K, 0, len(futures))
+	list := make([]Future[V], 0, len(futures))
+	for k, f := range futures {
+		keys = append(keys, k)
+		list = append(list, f)
+	}
+
+	return keys, list
+}
@@ -0,0 +1,73 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitAllMap(t *testing.T) {
+	t.Parallel()
+
+	// given
+	futures := map[string]async.Future[int]{"eu": resolved(1), "us": resolved(2)}
+
+	// when
+	values, err := async.AwaitAllMap(context.Background(), futures)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]int{"eu": 1, "us": 2}, values)
+	}
+}
+
+func TestAwaitAllMapFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	futures := map[string]async.Future[int]{"eu": rejected[int](errTest)}
+
+	// when
+	_, err := async.AwaitAllMap(context.Background(), futures)
+
+	// then
+	var keyErr async.AwaitKeyError[string]
+	if assert.ErrorAs(t, err, &keyErr) {
+		assert.Equal(t, "eu", keyErr.Key)
+	}
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestAwaitAllMapResults(t *testing.T) {
+	t.Parallel()
+
+	// given
+	futures := map[int]async.Future[string]{1: resolved("one"), 2: rejected[string](errTest)}
+
+	// when
+	results := async.AwaitAllMapResults(context.Background(), futures)
+
+	// then
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "one", results[1].Value())
+		assert.ErrorIs(t, results[2].Err(), errTest)
+	}
+}
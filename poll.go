@@ -0,0 +1,71 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidInterval is returned by futures of [Poll] called with an interval that is not positive.
+var ErrInvalidInterval = errors.New("invalid poll interval")
+
+// Poll calls check immediately and then every interval until it reports done or fails, resolving the returned
+// [Future] with its value or error. This is the way to await external systems that only offer "ask again later"
+// APIs, like operation status endpoints. When the context ends first, the future is rejected with its cause.
+// With an interval that is not positive, the future is rejected with [ErrInvalidInterval] without calling check.
+func Poll[R any](
+	ctx context.Context, interval time.Duration, check func(ctx context.Context) (R, bool, error),
+) Future[R] {
+	p, f := New[R]()
+	if interval <= 0 {
+		p.Reject(fmt.Errorf("%w: %v", ErrInvalidInterval, interval))
+
+		return f
+	}
+
+	go p.Do(func() (R, error) { return poll(ctx, interval, check) })
+
+	return f
+}
+
+func poll[R any](
+	ctx context.Context, interval time.Duration, check func(ctx context.Context) (R, bool, error),
+) (R, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return *new(R), cancelError(ctx, "poll")
+		}
+
+		value, done, err := check(ctx)
+		if err != nil || done {
+			return value, err
+		}
+
+		select {
+		case <-ticker.C:
+
+		case <-ctx.Done():
+			return *new(R), cancelError(ctx, "poll")
+		}
+	}
+}
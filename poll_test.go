@@ -0,0 +1,100 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	calls := 0
+	check := func(context.Context) (int, bool, error) {
+		calls++
+
+		return calls, calls == 3, nil
+	}
+
+	// when
+	f := async.Poll(context.Background(), time.Millisecond, check)
+	v, err := f.Await(context.Background())
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, v)
+	}
+}
+
+func TestPollError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	check := func(context.Context) (int, bool, error) { return 0, false, errTest }
+
+	// when
+	f := async.Poll(context.Background(), time.Hour, check)
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestPollCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	check := func(context.Context) (int, bool, error) {
+		cancel()
+
+		return 0, false, nil
+	}
+
+	// when
+	f := async.Poll(ctx, time.Hour, check)
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestPollInvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	// given
+	called := false
+	check := func(context.Context) (int, bool, error) {
+		called = true
+
+		return 0, true, nil
+	}
+
+	// when
+	f := async.Poll(context.Background(), 0, check)
+	_, err := f.Try()
+
+	// then
+	assert.ErrorIs(t, err, async.ErrInvalidInterval)
+	assert.False(t, called)
+}
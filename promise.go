@@ -25,13 +25,9 @@ type Promise[R any] struct {
 }
 
 func New[R any]() (Promise[R], Future[R]) {
-	r := value[R]{
-		done:  make(chan struct{}),
-		queue: make(chan []func(result result.Result[R]), 1),
-	}
-	r.queue <- nil
+	r := &value[R]{}
 
-	return Promise[R]{value: &r}, Future[R]{value: &r}
+	return Promise[R]{value: r}, Future[R]{value: r}
 }
 
 // func (p Promise[R]) Future() Future[R] { return Future[R]{value: p.value} }
@@ -43,10 +39,43 @@ func (p Promise[R]) Resolve(value R) {
 
 // Reject breaks the promise with an error.
 func (p Promise[R]) Reject(err error) {
-	p.complete(result.OfError[R](err))
+	p.complete(result.OfError[R](withRejectStack(err)))
 }
 
 // Do runs fn synchronously, fulfilling the [Promise] once it completes.
 func (p Promise[R]) Do(fn func() (R, error)) {
-	p.complete(result.Of(fn()))
+	value, err := fn()
+	p.complete(result.Of(value, withRejectStack(err)))
+}
+
+// tryReject breaks the promise with err unless it is already complete, for runners that may report a failure of
+// a task that already rejected its promise.
+func (p Promise[R]) tryReject(err error) {
+	_ = p.tryComplete(result.OfError[R](err))
+}
+
+// completeWith fulfills the promise with the result of f once it is complete.
+// With cycle detection enabled, the promise is rejected with [ErrDependencyCycle] when f depends on it.
+func (p Promise[R]) completeWith(f Future[R]) {
+	if detectCycles.Load() {
+		if reaches(f.node(), p.node()) {
+			p.complete(result.OfError[R](ErrDependencyCycle))
+
+			return
+		}
+		dependsOn(p.node(), f.node())
+	}
+	f.onComplete(p.complete)
+}
+
+// AwaiterCount returns the number of goroutines currently blocked in [Future.Await] on the corresponding future.
+// Producers can use this as a demand signal, deprioritizing or aborting work nobody waits for.
+func (p Promise[R]) AwaiterCount() int {
+	return int(p.awaiters.Load())
+}
+
+// WatchAwaiters calls fn with the new number of blocked awaiters whenever it changes, until stop is called.
+// fn runs synchronously on the awaiting goroutine and may be called concurrently, so it should be fast.
+func (p Promise[R]) WatchAwaiters(fn func(n int)) (stop func()) {
+	return p.watchAwaiters(fn)
 }
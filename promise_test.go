@@ -0,0 +1,59 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaiterCount(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var last atomic.Int32
+	changed := make(chan struct{}, 10)
+	stop := p.WatchAwaiters(func(n int) {
+		last.Store(int32(n))
+		changed <- struct{}{}
+	})
+	defer stop()
+
+	// when
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = f.Await(ctx)
+	}()
+	<-changed
+	during := p.AwaiterCount()
+	cancel()
+	<-done
+
+	// then
+	assert.Equal(t, 1, during)
+	assert.Equal(t, 0, p.AwaiterCount())
+	assert.Eventually(t, func() bool { return last.Load() == 0 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package promisegroup provides errgroup-style goroutine groups whose tasks return futures.
+package promisegroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"fillmore-labs.com/exp/async"
+)
+
+// Mode selects how a [Group] handles failing tasks.
+type Mode int
+
+const (
+	// FirstError cancels the group's context on the first failure, [Group.Wait] returns that error.
+	FirstError Mode = iota
+	// CollectAll runs all tasks to completion, [Group.Wait] returns all errors joined.
+	CollectAll
+)
+
+// Group is a collection of tasks working on subtasks of the same overall operation.
+type Group struct {
+	mode   Mode
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// New returns a new [Group] and a derived context, which is canceled on the first failure in [FirstError] mode or
+// when [Group.Wait] returns.
+func New(ctx context.Context, mode Mode) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return &Group{mode: mode, cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine and returns a future for its result.
+func Go[R any](g *Group, fn func() (R, error)) async.Future[R] {
+	p, f := async.New[R]()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		p.Do(func() (R, error) {
+			v, err := fn()
+			if err != nil {
+				g.fail(err)
+			}
+
+			return v, err
+		})
+	}()
+
+	return f
+}
+
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch g.mode {
+	case CollectAll:
+		g.errs = append(g.errs, err)
+
+	default:
+		if len(g.errs) == 0 {
+			g.errs = append(g.errs, err)
+			g.cancel(err)
+		}
+	}
+}
+
+// Wait blocks until all tasks are complete and returns the first error ([FirstError]) or all errors joined
+// ([CollectAll]).
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return errors.Join(g.errs...)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package promisegroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fillmore-labs.com/exp/async/promisegroup"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var (
+	errTest  = errors.New("test error")
+	errTest2 = errors.New("test error 2")
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestFirstError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	g, ctx := promisegroup.New(context.Background(), promisegroup.FirstError)
+
+	// when
+	f1 := promisegroup.Go(g, func() (int, error) { return 1, nil })
+	f2 := promisegroup.Go(g, func() (int, error) { return 0, errTest })
+	f3 := promisegroup.Go(g, func() (int, error) { <-ctx.Done(); return 0, errTest2 })
+	err := g.Wait()
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.NotErrorIs(t, err, errTest2)
+	v1, err1 := f1.Try()
+	if assert.NoError(t, err1) {
+		assert.Equal(t, 1, v1)
+	}
+	_, err2 := f2.Try()
+	assert.ErrorIs(t, err2, errTest)
+	_, err3 := f3.Try()
+	assert.ErrorIs(t, err3, errTest2)
+}
+
+func TestCollectAll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	g, ctx := promisegroup.New(context.Background(), promisegroup.CollectAll)
+
+	// when
+	_ = promisegroup.Go(g, func() (int, error) { return 0, errTest })
+	_ = promisegroup.Go(g, func() (int, error) { return 0, errTest2 })
+	f3 := promisegroup.Go(g, func() (error, error) { return ctx.Err(), nil })
+	err := g.Wait()
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errTest2)
+	ctxErr, _ := f3.Try()
+	assert.NoError(t, ctxErr)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
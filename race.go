@@ -0,0 +1,34 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import "context"
+
+// Race runs all functions concurrently and returns the result of the first to complete, preferring the lowest index
+// when several are ready. The contexts of the losing functions are canceled when it returns, so they can release their
+// resources. If the context is canceled, it returns early with an error.
+func Race[R any](ctx context.Context, fns ...func(ctx context.Context) (R, error)) (R, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // cancel the losers
+
+	futures := make([]Future[R], len(fns))
+	for i, fn := range fns {
+		futures[i], _ = NewAsyncCtx(ctx, fn)
+	}
+
+	return AwaitFirst(ctx, futures...)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	// given
+	started := make(chan struct{})
+	canceled := make(chan error, 1)
+	slow := func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		canceled <- ctx.Err()
+
+		return 0, ctx.Err()
+	}
+	fast := func(context.Context) (int, error) {
+		<-started
+
+		return 1, nil
+	}
+
+	// when
+	v, err := async.Race(context.Background(), slow, fast)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.ErrorIs(t, <-canceled, context.Canceled)
+}
+
+func TestRaceEmpty(t *testing.T) {
+	t.Parallel()
+
+	// when
+	_, err := async.Race[int](context.Background())
+
+	// then
+	assert.ErrorIs(t, err, async.ErrNoResult)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sort"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// AwaitTopK returns the best k values of the successful futures, best first, where less(a, b) reports whether a
+// ranks before b. Failed futures are skipped. Only k values are retained while results stream in. If the context is
+// canceled, it returns the best values so far together with an error.
+func AwaitTopK[R any](ctx context.Context, k int, less func(a, b R) bool, futures ...Future[R]) ([]R, error) {
+	return AwaitTopKUntil(ctx, k, less, nil, futures...)
+}
+
+// AwaitTopKUntil is like [AwaitTopK], but returns early once done reports true for the current best values.
+// A nil done never stops early.
+func AwaitTopKUntil[R any](
+	ctx context.Context, k int, less func(a, b R) bool, done func(top []R) bool, futures ...Future[R],
+) ([]R, error) {
+	if k < 1 {
+		return nil, nil
+	}
+
+	top := make([]R, 0, min(k, len(futures)))
+	var err error
+	AwaitAll(ctx, futures...)(func(_ int, r result.Result[R]) bool {
+		value, e := r.V()
+		if e != nil {
+			if ctx.Err() != nil {
+				err = cancelError(ctx, "top k")
+
+				return false
+			}
+
+			return true
+		}
+
+		idx := sort.Search(len(top), func(i int) bool { return less(value, top[i]) })
+		switch {
+		case len(top) < k:
+			top = append(top, value)
+			copy(top[idx+1:], top[idx:])
+			top[idx] = value
+
+		case idx < k:
+			copy(top[idx+1:], top[idx:k-1])
+			top[idx] = value
+
+		default:
+			return true
+		}
+
+		return done == nil || !done(top)
+	})
+
+	return top, err
+}
@@ -0,0 +1,95 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func resolved[R any](v R) async.Future[R] {
+	p, f := async.New[R]()
+	p.Resolve(v)
+
+	return f
+}
+
+func rejected[R any](err error) async.Future[R] {
+	p, f := async.New[R]()
+	p.Reject(err)
+
+	return f
+}
+
+func TestAwaitTopK(t *testing.T) {
+	t.Parallel()
+
+	// given
+	greater := func(a, b int) bool { return a > b }
+	futures := []async.Future[int]{
+		resolved(3), resolved(9), rejected[int](errTest), resolved(1), resolved(7), resolved(9), resolved(4),
+	}
+
+	// when
+	top, err := async.AwaitTopK(context.Background(), 3, greater, futures...)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{9, 9, 7}, top)
+	}
+}
+
+func TestAwaitTopKUntil(t *testing.T) {
+	t.Parallel()
+
+	// given
+	greater := func(a, b int) bool { return a > b }
+	_, pending := async.New[int]()
+	done := func(top []int) bool { return len(top) == 2 && top[0] >= 5 }
+
+	// when
+	top, err := async.AwaitTopKUntil(context.Background(), 2, greater, done, resolved(5), resolved(2), pending)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{5, 2}, top)
+	}
+}
+
+func TestAwaitTopKCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, pending := async.New[int]()
+	done := func([]int) bool {
+		cancel()
+
+		return false
+	}
+
+	// when
+	top, err := async.AwaitTopKUntil(ctx, 2, func(a, b int) bool { return a < b }, done, resolved(1), pending)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+	assert.Equal(t, []int{1}, top)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Refreshing holds a value that a producer replaces over time, like hot-reloaded configuration or credentials.
+// Each update increments the version, consumers read the current value or await the next change.
+type Refreshing[R any] struct {
+	_  noCopy
+	mu sync.Mutex
+
+	version uint64
+	current result.Result[R]
+	next    Promise[R] // completed with the next update
+	nextF   Future[R]
+}
+
+// NewRefreshing creates a [Refreshing] without a value, at version 0.
+func NewRefreshing[R any]() *Refreshing[R] {
+	p, f := New[R]()
+
+	return &Refreshing[R]{next: p, nextF: f}
+}
+
+// Set publishes a new value and returns its version.
+func (r *Refreshing[R]) Set(value R) uint64 {
+	return r.update(result.OfValue(value))
+}
+
+// Fail publishes an error, for example a failed reload, and returns its version.
+func (r *Refreshing[R]) Fail(err error) uint64 {
+	return r.update(result.OfError[R](err))
+}
+
+func (r *Refreshing[R]) update(res result.Result[R]) uint64 {
+	np, nf := New[R]()
+
+	r.mu.Lock()
+	r.version++
+	version, p := r.version, r.next
+	r.current, r.next, r.nextF = res, np, nf
+	r.mu.Unlock()
+
+	p.Do(res.V)
+
+	return version
+}
+
+// Current returns the current value with its version, waiting for the first one if necessary.
+// If the context is canceled, it returns early with an error.
+func (r *Refreshing[R]) Current(ctx context.Context) (R, uint64, error) {
+	for {
+		r.mu.Lock()
+		version, current, next := r.version, r.current, r.nextF
+		r.mu.Unlock()
+
+		if version > 0 {
+			v, err := current.V()
+
+			return v, version, err
+		}
+
+		select {
+		case <-next.Done():
+
+		case <-ctx.Done():
+			return *new(R), 0, cancelError(ctx, "refreshing current")
+		}
+	}
+}
+
+// Version returns the current version, 0 when no value has been published yet.
+func (r *Refreshing[R]) Version() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.version
+}
+
+// Changed returns a [Future] completed with the first value newer than version since, immediately when the current
+// version is newer already.
+func (r *Refreshing[R]) Changed(since uint64) Future[R] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.version > since {
+		p, f := New[R]()
+		p.Do(r.current.V)
+
+		return f
+	}
+
+	return r.nextF
+}
@@ -0,0 +1,85 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshing(t *testing.T) {
+	t.Parallel()
+
+	// given
+	r := async.NewRefreshing[string]()
+	first := r.Changed(0)
+
+	// when
+	v1 := r.Set("a")
+	second := r.Changed(v1)
+	v2 := r.Set("b")
+
+	// then
+	a, err := first.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "a", a)
+	}
+	b, err := second.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "b", b)
+	}
+	current, version, err := r.Current(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "b", current)
+		assert.Equal(t, v2, version)
+	}
+	assert.True(t, r.Changed(v1).IsDone())
+	assert.False(t, r.Changed(v2).IsDone())
+}
+
+func TestRefreshingCurrentWaits(t *testing.T) {
+	t.Parallel()
+
+	// given
+	r := async.NewRefreshing[int]()
+
+	// when
+	go r.Fail(errTest)
+	_, version, err := r.Current(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, uint64(1), version)
+}
+
+func TestRefreshingCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	r := async.NewRefreshing[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	_, _, err := r.Current(ctx)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+}
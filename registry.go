@@ -0,0 +1,170 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrExpired rejects futures of [Registry] entries not completed within the time to live.
+	ErrExpired = errors.New("registry entry expired")
+
+	// ErrDuplicateKey rejects futures registered with a key that is already in flight.
+	ErrDuplicateKey = errors.New("duplicate registry key")
+)
+
+// RegistryStats are counters of a [Registry].
+type RegistryStats struct {
+	Pending    int    // entries currently in flight
+	Registered uint64 // successful registrations
+	Completed  uint64 // entries resolved with Complete
+	Failed     uint64 // entries rejected with Fail or FailAll
+	Expired    uint64 // entries rejected with ErrExpired
+	Unknown    uint64 // Complete or Fail calls for keys not in flight, like late responses
+}
+
+// Registry maps in-flight request IDs to promises, as needed by protocol clients multiplexing responses over one
+// connection.
+type Registry[K comparable, R any] struct {
+	_   noCopy
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[K]*registryEntry[R]
+	stats   RegistryStats
+}
+
+type registryEntry[R any] struct {
+	p     Promise[R]
+	timer *time.Timer
+}
+
+// NewRegistry creates a [Registry] expiring entries after ttl, a ttl less or equal to zero disables expiry.
+func NewRegistry[K comparable, R any](ttl time.Duration) *Registry[K, R] {
+	return &Registry[K, R]{ttl: ttl, entries: make(map[K]*registryEntry[R])}
+}
+
+// Register adds an in-flight entry for k, returning the [Future] completed by [Registry.Complete] or
+// [Registry.Fail]. When k is already in flight, the future is rejected with [ErrDuplicateKey].
+func (r *Registry[K, R]) Register(k K) Future[R] {
+	p, f := New[R]()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[k]; ok {
+		p.Reject(fmt.Errorf("%w: %v", ErrDuplicateKey, k))
+
+		return f
+	}
+
+	e := &registryEntry[R]{p: p}
+	if r.ttl > 0 {
+		e.timer = time.AfterFunc(r.ttl, func() { r.expire(k, e) })
+	}
+	r.entries[k] = e
+	r.stats.Registered++
+
+	return f
+}
+
+// Complete resolves the entry for k with value, returning false when k is not in flight.
+func (r *Registry[K, R]) Complete(k K, value R) bool {
+	e := r.remove(k, func(s *RegistryStats) { s.Completed++ })
+	if e == nil {
+		return false
+	}
+	e.p.Resolve(value)
+
+	return true
+}
+
+// Fail rejects the entry for k with err, returning false when k is not in flight.
+func (r *Registry[K, R]) Fail(k K, err error) bool {
+	e := r.remove(k, func(s *RegistryStats) { s.Failed++ })
+	if e == nil {
+		return false
+	}
+	e.p.Reject(err)
+
+	return true
+}
+
+// FailAll rejects all in-flight entries with err, for example when the connection is lost.
+func (r *Registry[K, R]) FailAll(err error) {
+	r.mu.Lock()
+	entries := r.entries
+	r.entries = make(map[K]*registryEntry[R])
+	r.stats.Failed += uint64(len(entries))
+	r.mu.Unlock()
+
+	for _, e := range entries {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+		e.p.Reject(err)
+	}
+}
+
+// Stats returns a snapshot of the counters.
+func (r *Registry[K, R]) Stats() RegistryStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.stats
+	s.Pending = len(r.entries)
+
+	return s
+}
+
+// remove deletes the entry for k and updates the counters with count, returning nil when k is not in flight.
+func (r *Registry[K, R]) remove(k K, count func(s *RegistryStats)) *registryEntry[R] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[k]
+	if !ok {
+		r.stats.Unknown++
+
+		return nil
+	}
+	delete(r.entries, k)
+	count(&r.stats)
+	if e.timer != nil {
+		e.timer.Stop()
+	}
+
+	return e
+}
+
+func (r *Registry[K, R]) expire(k K, e *registryEntry[R]) {
+	r.mu.Lock()
+	if r.entries[k] != e { // completed or replaced in the meantime
+		r.mu.Unlock()
+
+		return
+	}
+	delete(r.entries, k)
+	r.stats.Expired++
+	r.mu.Unlock()
+
+	e.p.Reject(fmt.Errorf("%w after %v: %v", ErrExpired, r.ttl, k))
+}
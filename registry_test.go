@@ -0,0 +1,89 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	// given
+	r := async.NewRegistry[uint32, string](0)
+	f1 := r.Register(1)
+	f2 := r.Register(2)
+	dup := r.Register(1)
+
+	// when
+	ok1 := r.Complete(1, "one")
+	ok2 := r.Fail(2, errTest)
+	late := r.Complete(1, "late")
+
+	// then
+	assert.True(t, ok1)
+	assert.True(t, ok2)
+	assert.False(t, late)
+	if v, err := f1.Try(); assert.NoError(t, err) {
+		assert.Equal(t, "one", v)
+	}
+	_, err2 := f2.Try()
+	assert.ErrorIs(t, err2, errTest)
+	_, errDup := dup.Try()
+	assert.ErrorIs(t, errDup, async.ErrDuplicateKey)
+	assert.Equal(t, async.RegistryStats{Registered: 2, Completed: 1, Failed: 1, Unknown: 1}, r.Stats())
+}
+
+func TestRegistryExpiry(t *testing.T) {
+	t.Parallel()
+
+	// given
+	r := async.NewRegistry[string, int](time.Millisecond)
+
+	// when
+	f := r.Register("a")
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, async.ErrExpired)
+	assert.Equal(t, async.RegistryStats{Registered: 1, Expired: 1}, r.Stats())
+}
+
+func TestRegistryFailAll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	r := async.NewRegistry[int, int](time.Hour)
+	f1, f2 := r.Register(1), r.Register(2)
+
+	// when
+	pending := r.Stats().Pending
+	r.FailAll(errTest)
+
+	// then
+	assert.Equal(t, 2, pending)
+	for _, f := range []async.Future[int]{f1, f2} {
+		_, err := f.Try()
+		assert.ErrorIs(t, err, errTest)
+	}
+	assert.Zero(t, r.Stats().Pending)
+}
@@ -0,0 +1,252 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remotefuture transmits the completion of futures to other processes over net/rpc.
+//
+// This package is experimental. Values are gob-encoded, errors are transmitted as their message only.
+package remotefuture
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ServiceName is the name [Broker] should be registered under with the RPC server.
+const ServiceName = "Broker"
+
+var (
+	// ErrUnknownID is returned by proxies of ids that are not published in time or forgotten.
+	ErrUnknownID = errors.New("unknown remote future")
+
+	// ErrAlreadyPublished is returned by [Publish] for ids that are already published.
+	ErrAlreadyPublished = errors.New("remote future already published")
+)
+
+// Completion is the encoded result of a future as transmitted over the wire.
+type Completion struct {
+	Value []byte // gob-encoded value
+	Err   string // error message, if failed
+	Fail  bool   // whether the future was rejected
+}
+
+// RemoteError is the error of a future that was rejected in another process.
+type RemoteError string
+
+func (e RemoteError) Error() string {
+	return string(e)
+}
+
+// Broker is the producer side net/rpc service serving completions of published futures.
+type Broker struct {
+	publishTimeout time.Duration
+	mu             sync.Mutex
+	entries        map[string]*entry
+}
+
+type entry struct {
+	done       chan struct{} // closed on completion, or when forgotten before publishing
+	completion Completion
+	published  bool // guarded by Broker.mu
+	forgotten  bool // set before done is closed
+	waiters    int  // number of waits for an unpublished id, guarded by Broker.mu
+}
+
+// BrokerOption configures a [Broker].
+type BrokerOption func(b *Broker)
+
+// WithPublishTimeout sets how long [Broker.Wait] waits for an id to be published, 1 minute by default.
+func WithPublishTimeout(timeout time.Duration) BrokerOption {
+	return func(b *Broker) { b.publishTimeout = timeout }
+}
+
+// NewBroker creates an empty [Broker].
+func NewBroker(opts ...BrokerOption) *Broker {
+	b := &Broker{publishTimeout: time.Minute, entries: make(map[string]*entry)}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Publish makes the completion of f available to remote consumers under id. It fails with [ErrAlreadyPublished]
+// when id is already published and not forgotten.
+func Publish[R any](b *Broker, id string, f async.Future[R]) error {
+	b.mu.Lock()
+	e, ok := b.entries[id]
+	switch {
+	case !ok:
+		e = &entry{done: make(chan struct{})}
+		b.entries[id] = e
+
+	case e.published:
+		b.mu.Unlock()
+
+		return fmt.Errorf("%w: %q", ErrAlreadyPublished, id)
+	}
+	e.published = true
+	b.mu.Unlock()
+
+	f.OnComplete(func(r result.Result[R]) {
+		var c Completion
+		if err := r.Err(); err != nil {
+			c = Completion{Err: err.Error(), Fail: true}
+		} else {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(r.Value()); err != nil {
+				c = Completion{Err: fmt.Sprintf("encoding remote result: %v", err), Fail: true}
+			} else {
+				c = Completion{Value: buf.Bytes()}
+			}
+		}
+
+		e.completion = c
+		close(e.done)
+	})
+
+	return nil
+}
+
+// Forget removes the completion published under id. Waits for an id that is not published yet fail with
+// [ErrUnknownID].
+func (b *Broker) Forget(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.entries[id]
+	if !ok {
+		return
+	}
+	delete(b.entries, id)
+	if !e.published {
+		e.forgotten = true
+		close(e.done)
+	}
+}
+
+// Wait is the RPC method blocking until the future published under id is complete, so consumers may ask before the
+// producer publishes. It fails with [ErrUnknownID] when id is not published within the publish timeout or forgotten
+// before.
+func (b *Broker) Wait(id string, reply *Completion) error {
+	e := b.waitFor(id)
+
+	timer := time.NewTimer(b.publishTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-e.done:
+
+	case <-timer.C:
+		if b.abandon(id, e) {
+			return fmt.Errorf("%w: %q", ErrUnknownID, id)
+		}
+		<-e.done // published meanwhile
+	}
+
+	if e.forgotten {
+		return fmt.Errorf("%w: %q", ErrUnknownID, id)
+	}
+	*reply = e.completion
+
+	return nil
+}
+
+// waitFor returns the entry of id, creating a placeholder when it is not published yet.
+func (b *Broker) waitFor(id string) *entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.entries[id]
+	if !ok {
+		e = &entry{done: make(chan struct{})}
+		b.entries[id] = e
+	}
+	if !e.published {
+		e.waiters++
+	}
+
+	return e
+}
+
+// abandon gives up waiting for e to be published, dropping the placeholder with the last waiter. It returns false
+// when e was published meanwhile.
+func (b *Broker) abandon(id string, e *entry) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if e.published {
+		return false
+	}
+	e.waiters--
+	if e.waiters == 0 && b.entries[id] == e {
+		delete(b.entries, id)
+	}
+
+	return true
+}
+
+// Get returns a proxy future resolving with the result published under id on the broker reachable through client.
+// It fails with [ErrUnknownID] when id is not published in time. When ctx ends first, the proxy is rejected with an
+// [async.CanceledError]; the RPC itself completes in the background.
+func Get[R any](ctx context.Context, client *rpc.Client, id string) async.Future[R] {
+	p, f := async.New[R]()
+	var reply Completion
+	call := client.Go(ServiceName+".Wait", id, &reply, make(chan *rpc.Call, 1))
+
+	go func() {
+		select {
+		case <-call.Done:
+			p.Do(func() (R, error) { return decode[R](call.Error, reply) })
+
+		case <-ctx.Done():
+			p.Reject(async.NewCanceledError(ctx, "remote future"))
+		}
+	}()
+
+	return f
+}
+
+func decode[R any](callErr error, c Completion) (R, error) {
+	var v R
+	switch {
+	case callErr != nil:
+		var serverErr rpc.ServerError // errors arrive as text, restore the sentinel
+		if errors.As(callErr, &serverErr) {
+			if detail, ok := strings.CutPrefix(string(serverErr), ErrUnknownID.Error()); ok {
+				return v, fmt.Errorf("remote future: %w%s", ErrUnknownID, detail)
+			}
+		}
+
+		return v, fmt.Errorf("remote future: %w", callErr)
+
+	case c.Fail:
+		return v, RemoteError(c.Err)
+	}
+
+	err := gob.NewDecoder(bytes.NewReader(c.Value)).Decode(&v)
+
+	return v, err
+}
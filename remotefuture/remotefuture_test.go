@@ -0,0 +1,183 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remotefuture_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/remotefuture"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func connect(t *testing.T, b *remotefuture.Broker) *rpc.Client {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(remotefuture.ServiceName, b); err != nil {
+		t.Fatal(err)
+	}
+
+	serverConn, clientConn := net.Pipe()
+	go server.ServeConn(serverConn)
+
+	client := rpc.NewClient(clientConn)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+type payload struct {
+	Name  string
+	Count int
+}
+
+func TestRemoteValue(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := remotefuture.NewBroker()
+	client := connect(t, b)
+	p, f := async.New[payload]()
+
+	// when
+	proxy := remotefuture.Get[payload](context.Background(), client, "job-1")
+	errPublish := remotefuture.Publish(b, "job-1", f)
+	p.Resolve(payload{Name: "test", Count: 2})
+
+	// then
+	assert.NoError(t, errPublish)
+	v, err := proxy.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, payload{Name: "test", Count: 2}, v)
+	}
+}
+
+func TestRemoteError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := remotefuture.NewBroker()
+	client := connect(t, b)
+	p, f := async.New[int]()
+	_ = remotefuture.Publish(b, "job-2", f)
+	p.Reject(errors.New("remote failure"))
+
+	// when
+	proxy := remotefuture.Get[int](context.Background(), client, "job-2")
+
+	// then
+	_, err := proxy.Await(context.Background())
+	var remoteErr remotefuture.RemoteError
+	if assert.ErrorAs(t, err, &remoteErr) {
+		assert.Equal(t, "remote failure", remoteErr.Error())
+	}
+}
+
+func TestRemoteUnknownID(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := remotefuture.NewBroker(remotefuture.WithPublishTimeout(time.Millisecond))
+	client := connect(t, b)
+	p, f := async.New[int]()
+	_ = remotefuture.Publish(b, "job-3", f)
+	b.Forget("job-3")
+	p.Resolve(1)
+
+	// when
+	proxy := remotefuture.Get[int](context.Background(), client, "job-3")
+
+	// then
+	_, err := proxy.Await(context.Background())
+	assert.ErrorIs(t, err, remotefuture.ErrUnknownID)
+}
+
+func TestRemoteForgetWaiting(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := remotefuture.NewBroker()
+	client := connect(t, b)
+	proxy := remotefuture.Get[int](context.Background(), client, "job-6")
+
+	// when
+	forgotten := func() bool { // forgetting is a no-op until the wait arrives
+		b.Forget("job-6")
+
+		return proxy.IsDone()
+	}
+
+	// then
+	assert.Eventually(t, forgotten, time.Second, time.Millisecond)
+	_, err := proxy.Try()
+	assert.ErrorIs(t, err, remotefuture.ErrUnknownID)
+}
+
+func TestRemotePublishTwice(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := remotefuture.NewBroker()
+	client := connect(t, b)
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+	_ = remotefuture.Publish(b, "job-4", f1)
+
+	// when
+	err := remotefuture.Publish(b, "job-4", f2)
+	p1.Resolve(1)
+	p2.Resolve(2)
+
+	// then
+	assert.ErrorIs(t, err, remotefuture.ErrAlreadyPublished)
+	v, err := remotefuture.Get[int](context.Background(), client, "job-4").Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestRemoteCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	b := remotefuture.NewBroker()
+	client := connect(t, b)
+	p, f := async.New[int]()
+	defer p.Resolve(0)
+	_ = remotefuture.Publish(b, "job-5", f)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// when
+	proxy := remotefuture.Get[int](ctx, client, "job-5")
+	cancel()
+
+	// then
+	_, err := proxy.Await(context.Background())
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+}
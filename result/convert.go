@@ -0,0 +1,45 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package result
+
+import "errors"
+
+// ErrNilResult is returned by [Flatten] when a successful outer result holds a nil inner result.
+var ErrNilResult = errors.New("nil result")
+
+// As converts the value of a successful [Result] with convert, passing failures through unchanged.
+func As[T, U any](r Result[T], convert func(T) (U, error)) Result[U] {
+	value, err := r.V()
+	if err != nil {
+		return errorResult[U]{err: err}
+	}
+
+	return Of(convert(value))
+}
+
+// Flatten unwraps a nested [Result], failing with the outer error first.
+func Flatten[T any](r Result[Result[T]]) Result[T] {
+	inner, err := r.V()
+	if err != nil {
+		return errorResult[T]{err: err}
+	}
+	if inner == nil {
+		return errorResult[T]{err: ErrNilResult}
+	}
+
+	return inner
+}
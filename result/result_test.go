@@ -18,6 +18,7 @@ package result_test
 
 import (
 	"errors"
+	"strconv"
 	"testing"
 
 	"fillmore-labs.com/exp/async/result"
@@ -94,3 +95,82 @@ func TestAnyErr(t *testing.T) {
 	assert.ErrorIs(t, r2.Err(), errTest)
 	_ = r2.Value()
 }
+
+func sequence(results ...result.Result[int]) func(yield func(int, result.Result[int]) bool) {
+	return func(yield func(int, result.Result[int]) bool) {
+		for i, r := range results {
+			if !yield(i, r) {
+				return
+			}
+		}
+	}
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+	// given
+	seq := sequence(result.OfValue(1), result.OfError[int](errTest), result.OfValue(3))
+	// when
+	var indexes, values []int
+	result.Values(seq)(func(i, v int) bool {
+		indexes = append(indexes, i)
+		values = append(values, v)
+
+		return true
+	})
+	// then
+	assert.Equal(t, []int{0, 2}, indexes)
+	assert.Equal(t, []int{1, 3}, values)
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+	// given
+	seq := sequence(result.OfValue(1), result.OfError[int](errTest), result.OfError[int](errTest))
+	// when
+	var indexes []int
+	result.Errors(seq)(func(i int, err error) bool {
+		assert.ErrorIs(t, err, errTest)
+		indexes = append(indexes, i)
+
+		return false
+	})
+	// then
+	assert.Equal(t, []int{1}, indexes)
+}
+
+func TestAs(t *testing.T) {
+	t.Parallel()
+	// given
+	r1 := result.OfValue("12")
+	r2 := result.OfValue("x")
+	r3 := result.OfError[string](errTest)
+	// when
+	a1 := result.As(r1, strconv.Atoi)
+	a2 := result.As(r2, strconv.Atoi)
+	a3 := result.As(r3, strconv.Atoi)
+	// then
+	if v, err := a1.V(); assert.NoError(t, err) {
+		assert.Equal(t, 12, v)
+	}
+	assert.ErrorIs(t, a2.Err(), strconv.ErrSyntax)
+	assert.ErrorIs(t, a3.Err(), errTest)
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+	// given
+	r1 := result.OfValue(result.OfValue(1))
+	r2 := result.OfValue(result.OfError[int](errTest))
+	r3 := result.OfError[result.Result[int]](errTest)
+	r4 := result.OfValue[result.Result[int]](nil)
+	// when
+	f1, f2, f3, f4 := result.Flatten(r1), result.Flatten(r2), result.Flatten(r3), result.Flatten(r4)
+	// then
+	if v, err := f1.V(); assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.ErrorIs(t, f2.Err(), errTest)
+	assert.ErrorIs(t, f3.Err(), errTest)
+	assert.ErrorIs(t, f4.Err(), result.ErrNilResult)
+}
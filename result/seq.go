@@ -0,0 +1,43 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package result
+
+// Values returns a sequence of the indexes and values of successful results in seq, skipping failures.
+func Values[R any](seq func(yield func(int, Result[R]) bool)) func(yield func(int, R) bool) {
+	return func(yield func(int, R) bool) {
+		seq(func(i int, r Result[R]) bool {
+			if r.Err() != nil {
+				return true
+			}
+
+			return yield(i, r.Value())
+		})
+	}
+}
+
+// Errors returns a sequence of the indexes and errors of failed results in seq, skipping successes.
+func Errors[R any](seq func(yield func(int, Result[R]) bool)) func(yield func(int, error) bool) {
+	return func(yield func(int, error) bool) {
+		seq(func(i int, r Result[R]) bool {
+			if err := r.Err(); err != nil {
+				return yield(i, err)
+			}
+
+			return true
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrTaskPanicked is returned by futures of tasks submitted to a [Runner] that panicked.
+var ErrTaskPanicked = errors.New("task panicked")
+
+// Runner is implemented by worker pools accepting tasks, like the Pool of github.com/sourcegraph/conc/pool.
+type Runner interface {
+	Go(task func())
+}
+
+// RunnerFunc adapts a function submitting tasks to a [Runner].
+type RunnerFunc func(task func())
+
+// Go calls f(task).
+func (f RunnerFunc) Go(task func()) {
+	f(task)
+}
+
+// rejectingRunner is implemented by runners that may refuse tasks, like a [Limiter] with a bounded queue.
+type rejectingRunner interface {
+	submit(task func(), reject func(err error), block bool)
+}
+
+// goOrReject submits task to r, calling reject when r refuses it.
+func goOrReject(r Runner, reject func(err error), task func()) {
+	if rr, ok := r.(rejectingRunner); ok {
+		rr.submit(task, reject, true)
+
+		return
+	}
+	r.Go(task)
+}
+
+// enqueueOrReject is like goOrReject, but does not block on a full queue, for use in completion callbacks.
+func enqueueOrReject(r Runner, reject func(err error), task func()) {
+	if rr, ok := r.(rejectingRunner); ok {
+		rr.submit(task, reject, false)
+
+		return
+	}
+	r.Go(task)
+}
+
+// Submit runs fn on r and returns a [Future] for its result, so futures can share the worker budget of an existing
+// pool. When fn panics, the future is rejected with [ErrTaskPanicked] and the panic is propagated to the pool, where
+// a [Limiter] recovers it.
+func Submit[R any](r Runner, fn func() (R, error)) Future[R] {
+	p, f := New[R]()
+
+	goOrReject(r, p.tryReject, func() {
+		completed := false
+		defer func() {
+			if !completed {
+				p.Reject(ErrTaskPanicked)
+			}
+		}()
+
+		p.Do(fn)
+		completed = true
+	})
+
+	return f
+}
+
+// SubmitInline is like [Submit], but a goroutine awaiting the future before a worker of r started fn runs it
+// inline instead of blocking, falling back to r for concurrency. This saves goroutine hand-offs in call graphs that
+// are mostly sequential but written in async style, so fn should be a short computation. A panic in fn rejects the
+// future with [ErrTaskPanicked] and propagates to the goroutine running it, worker or awaiter.
+func SubmitInline[R any](r Runner, fn func() (R, error)) Future[R] {
+	p, f := New[R]()
+
+	var claimed atomic.Bool
+	task := func() {
+		if !claimed.CompareAndSwap(false, true) {
+			return // already run by an awaiter or a worker
+		}
+		f.inline.Store(nil)
+
+		completed := false
+		defer func() {
+			if !completed {
+				p.Reject(ErrTaskPanicked)
+			}
+		}()
+
+		p.Do(fn)
+		completed = true
+	}
+	f.inline.Store(&task)
+
+	goOrReject(r, func(err error) {
+		if claimed.CompareAndSwap(false, true) {
+			f.inline.Store(nil)
+			p.Reject(err)
+		}
+	}, task)
+
+	return f
+}
+
+// SubmitCtx is like [Submit], but passes fn its own context derived from ctx, so pooled tasks get the same
+// cancellation fidelity as goroutine-per-task execution. Calling abandon cancels the context of fn, and a task still
+// queued when its context ends is rejected without running fn. The context is canceled when fn returns.
+func SubmitCtx[R any](
+	ctx context.Context, r Runner, fn func(ctx context.Context) (R, error),
+) (f Future[R], abandon context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	f = Submit(r, func() (R, error) {
+		defer cancel()
+
+		return runCtx(ctx, fn)
+	})
+
+	return f, cancel
+}
+
+// NewAsyncCtx is like [NewAsync], but passes fn a context derived from ctx that is canceled by calling abandon or
+// when fn returns.
+func NewAsyncCtx[R any](
+	ctx context.Context, fn func(ctx context.Context) (R, error),
+) (f Future[R], abandon context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	f = NewAsync(func() (R, error) {
+		defer cancel()
+
+		return runCtx(ctx, fn)
+	})
+
+	return f, cancel
+}
+
+func runCtx[R any](ctx context.Context, fn func(ctx context.Context) (R, error)) (R, error) {
+	if ctx.Err() != nil {
+		return *new(R), cancelError(ctx, "task")
+	}
+
+	return fn(ctx)
+}
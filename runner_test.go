@@ -0,0 +1,237 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+// pool is a minimal conc-style worker pool.
+type pool struct {
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	panicked bool
+}
+
+func (p *pool) Go(task func()) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() {
+			if recover() != nil {
+				p.mu.Lock()
+				p.panicked = true
+				p.mu.Unlock()
+			}
+		}()
+		task()
+	}()
+}
+
+func TestSubmit(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var p pool
+
+	// when
+	f := async.Submit(&p, func() (int, error) { return 1, nil })
+	p.wg.Wait()
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestSubmitPanic(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var p pool
+
+	// when
+	f := async.Submit(&p, func() (int, error) { panic("test") })
+	p.wg.Wait()
+
+	// then
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, async.ErrTaskPanicked)
+	assert.True(t, p.panicked)
+}
+
+func TestRunnerFunc(t *testing.T) {
+	t.Parallel()
+
+	// given
+	inline := async.RunnerFunc(func(task func()) { task() })
+
+	// when
+	f := async.Submit(inline, func() (int, error) { return 0, errTest })
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestSubmitCtxAbandon(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var p pool
+	started := make(chan struct{})
+
+	// when
+	f, abandon := async.SubmitCtx(context.Background(), &p, func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+
+		return 0, ctx.Err()
+	})
+	<-started
+	abandon()
+	p.wg.Wait()
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSubmitCtxQueued(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var queued []func()
+	r := async.RunnerFunc(func(task func()) { queued = append(queued, task) })
+	called := false
+
+	// when
+	f, abandon := async.SubmitCtx(context.Background(), r, func(context.Context) (int, error) {
+		called = true
+
+		return 1, nil
+	})
+	abandon()
+	for _, task := range queued {
+		task()
+	}
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestNewAsyncCtx(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var taskCtx context.Context
+
+	// when
+	f, abandon := async.NewAsyncCtx(context.Background(), func(ctx context.Context) (int, error) {
+		taskCtx = ctx
+
+		return 1, nil
+	})
+	defer abandon()
+	v, err := f.Await(context.Background())
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.Error(t, taskCtx.Err()) // canceled after completion
+}
+
+func TestSubmitInline(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var queued []func()
+	r := async.RunnerFunc(func(task func()) { queued = append(queued, task) })
+	calls := 0
+
+	// when
+	f := async.SubmitInline(r, func() (int, error) {
+		calls++
+
+		return 1, nil
+	})
+	v, err := f.Await(context.Background())
+	for _, task := range queued {
+		task()
+	}
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.Len(t, queued, 1)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSubmitInlineWorker(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var p pool
+
+	// when
+	f := async.SubmitInline(&p, func() (int, error) { return 1, nil })
+	p.wg.Wait()
+
+	// then
+	v, err := f.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestSubmitInlineRejected(t *testing.T) {
+	t.Parallel()
+
+	// given
+	l := async.NewLimiter(1, async.WithQueue(1, async.RejectOnFull))
+	release := make(chan struct{})
+	blocker := async.Submit(l, func() (int, error) {
+		<-release
+
+		return 0, nil
+	})
+	queued := async.SubmitInline(l, func() (int, error) { return 1, nil })
+
+	// when
+	rejected := async.SubmitInline(l, func() (int, error) { return 2, nil })
+	v, err := queued.Await(context.Background())
+	close(release)
+	_, _ = blocker.Await(context.Background())
+
+	// then
+	_, errRejected := rejected.Await(context.Background())
+	assert.ErrorIs(t, errRejected, async.ErrQueueFull)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
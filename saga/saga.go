@@ -0,0 +1,200 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package saga orchestrates multi-step side-effecting workflows, compensating completed steps when a later one fails.
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"fillmore-labs.com/exp/async"
+)
+
+var (
+	// ErrStarted is returned when steps are added to or a saga is run after it was started.
+	ErrStarted = errors.New("saga already started")
+
+	// ErrAborted rejects the futures of steps that did not run because an earlier step failed.
+	ErrAborted = errors.New("saga aborted")
+)
+
+// Saga is a builder for sequential steps, each with a compensation undoing its effect.
+type Saga struct {
+	mu      sync.Mutex
+	started bool
+	steps   []step
+}
+
+type step struct {
+	name       string
+	run        func(ctx context.Context) error
+	compensate func(ctx context.Context) error
+	abort      func()
+}
+
+// New creates an empty [Saga].
+func New() *Saga {
+	return &Saga{}
+}
+
+// Step adds an action to s, returning a future for its value. When a later step fails, compensate is called with the
+// value to undo the action. A nil compensate means the action needs no undo.
+func Step[R any](
+	s *Saga, name string, action func(ctx context.Context) (R, error),
+	compensate func(ctx context.Context, value R) error,
+) async.Future[R] {
+	p, f := async.New[R]()
+
+	var value R
+	st := step{
+		name: name,
+		run: func(ctx context.Context) error {
+			var err error
+			value, err = action(ctx)
+			if err != nil {
+				p.Reject(err)
+
+				return err
+			}
+			p.Resolve(value)
+
+			return nil
+		},
+		abort: func() { p.Reject(ErrAborted) },
+	}
+	if compensate != nil {
+		st.compensate = func(ctx context.Context) error { return compensate(ctx, value) }
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		p.Reject(ErrStarted)
+	} else {
+		s.steps = append(s.steps, st)
+	}
+
+	return f
+}
+
+// Run executes the steps asynchronously in order. When a step fails, the following steps are aborted and the
+// compensations of completed steps run in reverse order, with a context that is not canceled together with ctx.
+// The returned completion fails with a [*Report] in that case.
+func (s *Saga) Run(ctx context.Context) async.Completion {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		c, f := async.NewCompletion()
+		c.Reject(ErrStarted)
+
+		return f
+	}
+	s.started = true
+
+	return async.NewAsyncCompletion(func() error { return run(ctx, s.steps) })
+}
+
+func run(ctx context.Context, steps []step) error {
+	for i, st := range steps {
+		if ctx.Err() != nil {
+			abort(steps[i:])
+
+			return compensate(context.WithoutCancel(ctx), steps[:i], st.name,
+				async.NewCanceledError(ctx, "saga"))
+		}
+
+		if err := st.run(ctx); err != nil {
+			abort(steps[i+1:])
+
+			return compensate(context.WithoutCancel(ctx), steps[:i], st.name, err)
+		}
+	}
+
+	return nil
+}
+
+func abort(steps []step) {
+	for _, st := range steps {
+		st.abort()
+	}
+}
+
+func compensate(ctx context.Context, completed []step, failed string, err error) *Report {
+	r := &Report{Step: failed, Err: err}
+	for i := len(completed) - 1; i >= 0; i-- {
+		st := completed[i]
+		if st.compensate == nil {
+			continue
+		}
+		r.Compensations = append(r.Compensations, Compensation{Step: st.name, Err: st.compensate(ctx)})
+	}
+
+	return r
+}
+
+// Compensation is the outcome of undoing a step.
+type Compensation struct {
+	Step string
+	Err  error // nil when the compensation succeeded
+}
+
+// Report describes a failed saga: the failing step and the compensations run, in execution order.
+type Report struct {
+	Step          string
+	Err           error
+	Compensations []Compensation
+}
+
+// Compensated reports whether all compensations succeeded.
+func (r *Report) Compensated() bool {
+	for _, c := range r.Compensations {
+		if c.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Report) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "saga step %q: %v", r.Step, r.Err)
+	for _, c := range r.Compensations {
+		if c.Err != nil {
+			fmt.Fprintf(&b, "; compensating %q: %v", c.Step, c.Err)
+		}
+	}
+
+	return b.String()
+}
+
+// Unwrap returns the error of the failed step followed by the errors of failed compensations.
+func (r *Report) Unwrap() []error {
+	errs := []error{r.Err}
+	for _, c := range r.Compensations {
+		if c.Err != nil {
+			errs = append(errs, c.Err)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,143 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/saga"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var errTest = errors.New("test error")
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestSaga(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s := saga.New()
+	reservation := saga.Step(s, "reserve", func(context.Context) (string, error) { return "r1", nil }, nil)
+	charge := saga.Step(s, "charge", func(context.Context) (int, error) { return 42, nil }, nil)
+
+	// when
+	err := s.Run(context.Background()).Await(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	if v, err := reservation.Try(); assert.NoError(t, err) {
+		assert.Equal(t, "r1", v)
+	}
+	if v, err := charge.Try(); assert.NoError(t, err) {
+		assert.Equal(t, 42, v)
+	}
+}
+
+func TestSagaCompensation(t *testing.T) {
+	t.Parallel()
+
+	// given
+	errRefund := errors.New("refund failed")
+	var undone []string
+	s := saga.New()
+	_ = saga.Step(s, "reserve",
+		func(context.Context) (string, error) { return "r1", nil },
+		func(_ context.Context, id string) error {
+			undone = append(undone, "release "+id)
+
+			return nil
+		})
+	_ = saga.Step(s, "charge",
+		func(context.Context) (int, error) { return 42, nil },
+		func(_ context.Context, amount int) error {
+			undone = append(undone, "refund")
+
+			return errRefund
+		})
+	_ = saga.Step(s, "ship", func(context.Context) (int, error) { return 0, errTest }, nil)
+	track := saga.Step(s, "track", func(context.Context) (int, error) { return 1, nil }, nil)
+
+	// when
+	err := s.Run(context.Background()).Await(context.Background())
+
+	// then
+	var report *saga.Report
+	if assert.ErrorAs(t, err, &report) {
+		assert.Equal(t, "ship", report.Step)
+		assert.False(t, report.Compensated())
+		assert.Equal(t, []saga.Compensation{{Step: "charge", Err: errRefund}, {Step: "reserve"}}, report.Compensations)
+	}
+	assert.ErrorIs(t, err, errTest)
+	assert.ErrorIs(t, err, errRefund)
+	assert.Equal(t, []string{"refund", "release r1"}, undone)
+	_, errTrack := track.Try()
+	assert.ErrorIs(t, errTrack, saga.ErrAborted)
+}
+
+func TestSagaStarted(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s := saga.New()
+	ctx := context.Background()
+	_ = s.Run(ctx).Await(ctx)
+
+	// when
+	f := saga.Step(s, "late", func(context.Context) (int, error) { return 1, nil }, nil)
+	err := s.Run(ctx).Await(ctx)
+
+	// then
+	_, errStep := f.Try()
+	assert.ErrorIs(t, errStep, saga.ErrStarted)
+	assert.ErrorIs(t, err, saga.ErrStarted)
+}
+
+func TestSagaCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	var undone []string
+	s := saga.New()
+	_ = saga.Step(s, "reserve",
+		func(context.Context) (string, error) {
+			cancel()
+
+			return "r1", nil
+		},
+		func(_ context.Context, id string) error {
+			undone = append(undone, "release "+id)
+
+			return nil
+		})
+	_ = saga.Step(s, "charge", func(context.Context) (int, error) { return 42, nil }, nil)
+
+	// when
+	err := s.Run(ctx).Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"release r1"}, undone)
+}
@@ -0,0 +1,91 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Scatter calls fn for every key with at most limit calls in flight (limit less than 1 means no limit), gathers
+// the values in key order and combines them with merge.
+// When a call fails, the context passed to the remaining calls is canceled and the first error is returned. Scatter
+// returns only after all started calls are complete.
+func Scatter[K, R any](
+	ctx context.Context, limit int, keys []K, fn func(ctx context.Context, key K) (R, error), merge func([]R) (R, error),
+) (R, error) {
+	taskCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var sem chan struct{}
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	var failOnce sync.Once
+	var failure error // the first failing call, remaining calls might fail because of the cancellation
+
+	futures := make([]Future[R], len(keys))
+	for i, key := range keys {
+		p, f := New[R]()
+		futures[i] = f
+
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+
+			case <-taskCtx.Done():
+				p.Reject(cancelError(taskCtx, "scatter"))
+
+				continue
+			}
+		}
+
+		wg.Add(1)
+		i, key := i, key
+		go func() {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+
+			r := result.Of(fn(taskCtx, key))
+			if err := r.Err(); err != nil {
+				failOnce.Do(func() { failure = AwaitError{Index: i, Err: err} })
+				cancel(err)
+			}
+			p.complete(r)
+		}()
+	}
+
+	values, err := AwaitAllValues(ctx, futures...)
+	if err != nil {
+		wg.Wait()
+		if failure != nil {
+			return *new(R), failure
+		}
+
+		return *new(R), err
+	}
+
+	return merge(values)
+}
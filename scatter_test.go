@@ -0,0 +1,85 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(values []int) (int, error) {
+	var total int
+	for _, v := range values {
+		total += v
+	}
+
+	return total, nil
+}
+
+func TestScatter(t *testing.T) {
+	t.Parallel()
+
+	// given
+	keys := []int{1, 2, 3, 4, 5}
+	var running, maxRunning atomic.Int32
+	square := func(_ context.Context, k int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for m := maxRunning.Load(); n > m && !maxRunning.CompareAndSwap(m, n); m = maxRunning.Load() {
+		}
+
+		return k * k, nil
+	}
+
+	// when
+	v, err := async.Scatter(context.Background(), 2, keys, square, sum)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 55, v)
+	}
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+}
+
+func TestScatterFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	keys := []int{0, 1, 2}
+	fn := func(ctx context.Context, k int) (int, error) {
+		if k == 1 {
+			return 0, errTest
+		}
+		<-ctx.Done()
+
+		return 0, context.Cause(ctx)
+	}
+
+	// when
+	_, err := async.Scatter(context.Background(), 0, keys, fn, sum)
+
+	// then
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 1, awaitErr.Index)
+	}
+	assert.ErrorIs(t, err, errTest)
+}
@@ -0,0 +1,197 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schedule runs periodic jobs whose runs are awaitable futures.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+)
+
+// Schedule computes the activation times of a job.
+// The interface matches the schedules of common cron expression parsers, so those can be used directly.
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Every returns a [Schedule] activating at fixed intervals. Like [time.NewTicker], it panics when d is not positive.
+func Every(d time.Duration) Schedule {
+	if d <= 0 {
+		panic(errors.New("non-positive interval for schedule.Every"))
+	}
+
+	return interval(d)
+}
+
+type interval time.Duration
+
+func (i interval) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(i))
+}
+
+// OverlapPolicy decides what happens when a run is due while the previous one is still active.
+type OverlapPolicy int
+
+const (
+	// Skip drops the due run.
+	Skip OverlapPolicy = iota
+	// Allow starts the due run concurrently.
+	Allow
+	// Wait starts the due run once the previous one is complete.
+	Wait
+)
+
+// ErrStopped is the error of the status future when the job was stopped through its context.
+var ErrStopped = errors.New("schedule stopped")
+
+// Job is the periodic function, it receives a context that ends when the scheduler's context does.
+type Job[R any] func(ctx context.Context) (R, error)
+
+// Runs controls a scheduled job and provides access to its runs.
+type Runs[R any] struct {
+	runs   chan async.Future[R]
+	status async.Future[struct{}]
+	stop   chan struct{}
+	once   sync.Once
+}
+
+// Start schedules job until [Runs.Stop] is called or ctx ends.
+func Start[R any](ctx context.Context, sched Schedule, policy OverlapPolicy, job Job[R]) *Runs[R] {
+	p, f := async.New[struct{}]()
+	r := &Runs[R]{
+		runs:   make(chan async.Future[R]),
+		status: f,
+		stop:   make(chan struct{}),
+	}
+
+	go r.loop(ctx, sched, policy, job, p)
+
+	return r
+}
+
+// Runs returns a channel receiving a future for every started run. It is closed when the scheduler shuts down.
+// The scheduler waits for runs to be received, so consumers should drain the channel.
+func (r *Runs[R]) Runs() <-chan async.Future[R] {
+	return r.runs
+}
+
+// Status returns a future that resolves after a graceful shutdown once all runs are complete, or is rejected with
+// [ErrStopped] when the context ended.
+func (r *Runs[R]) Status() async.Future[struct{}] {
+	return r.status
+}
+
+// Stop gracefully shuts down the scheduler: no new runs are started, active runs complete normally.
+func (r *Runs[R]) Stop() {
+	r.once.Do(func() { close(r.stop) })
+}
+
+func (r *Runs[R]) loop(
+	ctx context.Context, sched Schedule, policy OverlapPolicy, job Job[R], status async.Promise[struct{}],
+) {
+	var wg sync.WaitGroup
+	var last async.Future[R]
+	started := false
+
+	defer func() {
+		wg.Wait()
+		close(r.runs)
+		if err := ctx.Err(); err != nil {
+			status.Reject(errors.Join(ErrStopped, async.NewCanceledError(ctx, "schedule")))
+		} else {
+			status.Resolve(struct{}{})
+		}
+	}()
+
+	timer := time.NewTimer(time.Until(sched.Next(time.Now())))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-r.stop:
+			return
+
+		case now := <-timer.C:
+			if started && !r.ready(ctx, policy, last) {
+				if policy == Skip {
+					timer.Reset(time.Until(sched.Next(now)))
+
+					continue
+				}
+
+				return
+			}
+
+			last, started = r.run(ctx, &wg, job), true
+			select {
+			case r.runs <- last:
+
+			case <-ctx.Done():
+				return
+
+			case <-r.stop:
+				return
+			}
+
+			timer.Reset(time.Until(sched.Next(now)))
+		}
+	}
+}
+
+// ready reports whether a new run may start according to policy, waiting for the previous run when requested.
+func (r *Runs[R]) ready(ctx context.Context, policy OverlapPolicy, last async.Future[R]) bool {
+	switch policy {
+	case Allow:
+		return true
+
+	case Wait:
+		select {
+		case <-last.Done():
+			return true
+
+		case <-ctx.Done():
+			return false
+
+		case <-r.stop:
+			return false
+		}
+
+	default:
+		_, err := last.Try()
+
+		return !errors.Is(err, async.ErrNotReady)
+	}
+}
+
+func (r *Runs[R]) run(ctx context.Context, wg *sync.WaitGroup, job Job[R]) async.Future[R] {
+	p, f := async.New[R]()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.Do(func() (R, error) { return job(ctx) })
+	}()
+
+	return f
+}
@@ -0,0 +1,118 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package schedule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async/schedule"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestEvery(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var count int
+	job := func(context.Context) (int, error) { count++; return count, nil }
+
+	// when
+	r := schedule.Start(context.Background(), schedule.Every(time.Millisecond), schedule.Wait, job)
+
+	var values []int
+	for f := range r.Runs() {
+		v, err := f.Await(context.Background())
+		if assert.NoError(t, err) {
+			values = append(values, v)
+		}
+		if len(values) == 3 {
+			r.Stop()
+		}
+	}
+
+	// then
+	assert.Equal(t, []int{1, 2, 3}, values[:3])
+	_, err := r.Status().Await(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestEveryInvalid(t *testing.T) {
+	t.Parallel()
+
+	// when
+	every := func() { schedule.Every(0) }
+
+	// then
+	assert.Panics(t, every)
+}
+
+func TestSkipOverlap(t *testing.T) {
+	t.Parallel()
+
+	// given
+	release := make(chan struct{})
+	job := func(context.Context) (struct{}, error) { <-release; return struct{}{}, nil }
+
+	// when
+	r := schedule.Start(context.Background(), schedule.Every(time.Millisecond), schedule.Skip, job)
+	first := <-r.Runs()
+
+	var overlapping bool
+	select {
+	case <-r.Runs():
+		overlapping = true
+
+	case <-time.After(20 * time.Millisecond):
+	}
+	r.Stop()
+	close(release)
+	for range r.Runs() {
+	}
+
+	// then
+	assert.False(t, overlapping)
+	_, err := first.Await(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestContextStops(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	job := func(ctx context.Context) (int, error) { <-ctx.Done(); return 0, ctx.Err() }
+
+	// when
+	r := schedule.Start(ctx, schedule.Every(time.Millisecond), schedule.Allow, job)
+	f := <-r.Runs()
+	cancel()
+	for range r.Runs() {
+	}
+
+	// then
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = r.Status().Await(context.Background())
+	assert.ErrorIs(t, err, schedule.ErrStopped)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Scope is a [Runner] attaching labels to all tasks of a fan-out, so observability is configured once instead of per
+// future. Tasks run on new goroutines carrying the scope's pprof labels inside a trace region named after the scope.
+//
+// Use it with [Submit], [SubmitCtx] or [AndThenOn], passing [Scope.Context] to propagate the labels further.
+type Scope struct {
+	ctx  context.Context //nolint:containedctx // carries the labels
+	name string
+}
+
+// NewScope creates a [Scope] with the given name and labels. Labels are key-value pairs like for [pprof.Labels],
+// which panics on an odd number of arguments.
+func NewScope(ctx context.Context, name string, labels ...string) *Scope {
+	return &Scope{ctx: pprof.WithLabels(ctx, pprof.Labels(labels...)), name: name}
+}
+
+// With returns a child [Scope] with additional labels, overriding existing labels with the same key.
+func (s *Scope) With(labels ...string) *Scope {
+	return &Scope{ctx: pprof.WithLabels(s.ctx, pprof.Labels(labels...)), name: s.name}
+}
+
+// Context returns the context carrying the scope's labels.
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Name returns the name of the scope, which is also the type of its trace regions.
+func (s *Scope) Name() string {
+	return s.name
+}
+
+// Label returns the value of a label of the scope.
+func (s *Scope) Label(key string) (string, bool) {
+	return pprof.Label(s.ctx, key)
+}
+
+// Go runs task on a new goroutine labeled with the scope's labels, inside a trace region.
+func (s *Scope) Go(task func()) {
+	go func() {
+		pprof.SetGoroutineLabels(s.ctx)
+		defer trace.StartRegion(s.ctx, s.name).End()
+
+		task()
+	}()
+}
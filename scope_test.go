@@ -0,0 +1,68 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s := async.NewScope(context.Background(), "fanout", "service", "billing", "operation", "sync")
+
+	// when
+	f, abandon := async.SubmitCtx(s.Context(), s, func(ctx context.Context) (string, error) {
+		v, _ := pprof.Label(ctx, "service")
+
+		return v, nil
+	})
+	defer abandon()
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "billing", v)
+	}
+	assert.Equal(t, "fanout", s.Name())
+}
+
+func TestScopeWith(t *testing.T) {
+	t.Parallel()
+
+	// given
+	s := async.NewScope(context.Background(), "fanout", "service", "billing", "operation", "sync")
+
+	// when
+	child := s.With("operation", "refund", "tenant", "t1")
+
+	// then
+	service, _ := child.Label("service")
+	operation, _ := child.Label("operation")
+	tenant, _ := child.Label("tenant")
+	assert.Equal(t, "billing", service)
+	assert.Equal(t, "refund", operation)
+	assert.Equal(t, "t1", tenant)
+	parent, _ := s.Label("operation")
+	assert.Equal(t, "sync", parent)
+}
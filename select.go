@@ -0,0 +1,70 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+)
+
+// Select2 waits until one of both futures is complete and returns its index, preferring the lower index when both
+// are ready. If the context is canceled, it returns early with an error.
+func Select2(ctx context.Context, f0, f1 AnyFuture) (int, error) {
+	d0, d1 := f0.Done(), f1.Done()
+	select {
+	case <-d0:
+		return 0, nil
+
+	case <-d1:
+		return selectLowest(1, d0), nil
+
+	case <-ctx.Done():
+		return -1, cancelError(ctx, "select")
+	}
+}
+
+// Select3 waits until one of the futures is complete and returns its index, preferring the lowest index when
+// several are ready. If the context is canceled, it returns early with an error.
+func Select3(ctx context.Context, f0, f1, f2 AnyFuture) (int, error) {
+	d0, d1, d2 := f0.Done(), f1.Done(), f2.Done()
+	select {
+	case <-d0:
+		return 0, nil
+
+	case <-d1:
+		return selectLowest(1, d0), nil
+
+	case <-d2:
+		return selectLowest(2, d0, d1), nil
+
+	case <-ctx.Done():
+		return -1, cancelError(ctx, "select")
+	}
+}
+
+// selectLowest returns the index of the first closed channel in lower, or chosen when none is.
+func selectLowest(chosen int, lower ...<-chan struct{}) int {
+	for i, d := range lower {
+		select {
+		case <-d:
+			return i
+
+		default:
+		}
+	}
+
+	return chosen
+}
@@ -0,0 +1,92 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelect2(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f0 := async.New[int]()
+	p1, f1 := async.New[string]()
+	p1.Resolve("test")
+
+	// when
+	i, err := async.Select2(context.Background(), f0, f1)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, i)
+	}
+}
+
+func TestSelect3PrefersLowest(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f0 := async.New[int]()
+	p1, f1 := async.New[string]()
+	p2, f2 := async.New[struct{}]()
+	p2.Resolve(struct{}{})
+	p1.Resolve("test")
+
+	// when
+	i, err := async.Select3(context.Background(), f0, f1, f2)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, i)
+	}
+}
+
+func TestSelectCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f0 := async.New[int]()
+	_, f1 := async.New[int]()
+	_, f2 := async.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	_, err2 := async.Select2(ctx, f0, f1)
+	_, err3 := async.Select3(ctx, f0, f1, f2)
+
+	// then
+	assert.ErrorIs(t, err2, context.Canceled)
+	assert.ErrorIs(t, err3, context.Canceled)
+}
+
+func BenchmarkSelect2(b *testing.B) {
+	_, f0 := async.New[int]()
+	p1, f1 := async.New[int]()
+	p1.Resolve(1)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = async.Select2(ctx, f0, f1)
+	}
+}
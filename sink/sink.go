@@ -0,0 +1,254 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sink streams settled results of futures to pluggable storage in the background.
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrClosed is returned when using a closed [Sink].
+var ErrClosed = errors.New("sink closed")
+
+// Record is the settled result of a watched future.
+type Record[R any] struct {
+	Key   string    // key passed to [Sink.Watch]
+	Value R         // value of a successful future
+	Err   error     // error of a failed future
+	Time  time.Time // time of settlement
+}
+
+// Writer persists batches of records, for example to a file, a database or a message bus.
+type Writer[R any] interface {
+	Write(ctx context.Context, records []Record[R]) error
+}
+
+// WriterFunc adapts a function to a [Writer].
+type WriterFunc[R any] func(ctx context.Context, records []Record[R]) error
+
+// Write calls f(ctx, records).
+func (f WriterFunc[R]) Write(ctx context.Context, records []Record[R]) error {
+	return f(ctx, records)
+}
+
+// Option configures a [Sink].
+type Option func(opts *options)
+
+type options struct {
+	batchSize int
+	interval  time.Duration
+	attempts  int
+	backoff   time.Duration
+}
+
+// WithBatchSize sets the maximum number of records per write, 100 by default. A full batch is written immediately.
+func WithBatchSize(size int) Option {
+	return func(opts *options) { opts.batchSize = max(size, 1) }
+}
+
+// WithFlushInterval sets the interval after which incomplete batches are written, 1 second by default. Intervals that
+// are not positive keep the default.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		if interval > 0 {
+			opts.interval = interval
+		}
+	}
+}
+
+// WithRetry sets the number of write attempts per batch, 3 by default, with a linearly increasing delay of backoff
+// between attempts.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(opts *options) { opts.attempts, opts.backoff = max(attempts, 1), backoff }
+}
+
+// Sink collects the results of watched futures and writes them in batches from a background goroutine.
+type Sink[R any] struct {
+	ctx     context.Context //nolint:containedctx // passed to the writer
+	w       Writer[R]
+	opts    options
+	pending sync.WaitGroup // watched futures not settled yet
+	signal  chan struct{}  // a full batch is available
+	closing chan struct{}
+	done    chan struct{}
+
+	mu     sync.Mutex
+	queue  []Record[R]
+	closed bool
+	errs   []error
+}
+
+// New creates a [Sink] writing to w. The context is passed to w and ends retries when canceled.
+func New[R any](ctx context.Context, w Writer[R], opts ...Option) *Sink[R] {
+	o := options{batchSize: 100, interval: time.Second, attempts: 3, backoff: 100 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	s := &Sink[R]{
+		ctx:     ctx,
+		w:       w,
+		opts:    o,
+		signal:  make(chan struct{}, 1),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+
+	return s
+}
+
+// Watch records the result of f under key once it settles.
+func (s *Sink[R]) Watch(key string, f async.Future[R]) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return ErrClosed
+	}
+	s.pending.Add(1)
+	s.mu.Unlock()
+
+	f.OnComplete(func(r result.Result[R]) {
+		defer s.pending.Done()
+
+		v, err := r.V()
+		s.mu.Lock()
+		s.queue = append(s.queue, Record[R]{Key: key, Value: v, Err: err, Time: time.Now()})
+		full := len(s.queue) >= s.opts.batchSize
+		s.mu.Unlock()
+
+		if full {
+			select {
+			case s.signal <- struct{}{}:
+			default:
+			}
+		}
+	})
+
+	return nil
+}
+
+// Close stops accepting futures, waits until the watched ones settle or ctx is canceled, writes the remaining
+// records and stops the background goroutine. It returns the errors of batches dropped after all write attempts
+// failed.
+func (s *Sink[R]) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return ErrClosed
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	settled := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(settled)
+	}()
+
+	var err error
+	select {
+	case <-settled:
+
+	case <-ctx.Done():
+		err = async.NewCanceledError(ctx, "sink close")
+	}
+
+	close(s.closing)
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return errors.Join(append(s.errs, err)...)
+}
+
+func (s *Sink[R]) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.signal:
+			s.flush(false)
+
+		case <-ticker.C:
+			s.flush(true)
+
+		case <-s.closing:
+			s.flush(true)
+
+			return
+		}
+	}
+}
+
+// flush writes queued records in batches, including a final incomplete batch when all is set.
+func (s *Sink[R]) flush(all bool) {
+	for {
+		s.mu.Lock()
+		n := min(len(s.queue), s.opts.batchSize)
+		if n == 0 || !all && n < s.opts.batchSize {
+			s.mu.Unlock()
+
+			return
+		}
+		batch := s.queue[:n:n]
+		s.queue = s.queue[n:]
+		s.mu.Unlock()
+
+		if err := s.write(batch); err != nil {
+			s.mu.Lock()
+			s.errs = append(s.errs, err)
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *Sink[R]) write(batch []Record[R]) error {
+	for attempt := 1; ; attempt++ {
+		err := s.w.Write(s.ctx, batch)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= s.opts.attempts {
+			return fmt.Errorf("sink: dropped %d records after %d attempts: %w", len(batch), attempt, err)
+		}
+
+		timer := time.NewTimer(s.opts.backoff * time.Duration(attempt))
+		select {
+		case <-timer.C:
+
+		case <-s.ctx.Done():
+			timer.Stop()
+
+			return fmt.Errorf("sink: dropped %d records: %w", len(batch), errors.Join(err, async.NewCanceledError(s.ctx, "sink write")))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sink_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/sink"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+var errTest = errors.New("test error")
+
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]sink.Record[int]
+	fail    int // number of writes to fail
+}
+
+func (r *recorder) Write(_ context.Context, records []sink.Record[int]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.fail > 0 {
+		r.fail--
+
+		return errTest
+	}
+	r.batches = append(r.batches, records)
+
+	return nil
+}
+
+func TestSink(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var w recorder
+	s := sink.New[int](context.Background(), &w, sink.WithBatchSize(2), sink.WithFlushInterval(time.Hour))
+	promises := make([]async.Promise[int], 3)
+	for i, key := range []string{"a", "b", "c"} {
+		var f async.Future[int]
+		promises[i], f = async.New[int]()
+		_ = s.Watch(key, f)
+	}
+
+	// when
+	promises[0].Resolve(1)
+	promises[1].Reject(errTest)
+	promises[2].Resolve(3)
+	err := s.Close(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	if assert.Len(t, w.batches, 2) {
+		assert.Len(t, w.batches[0], 2)
+		assert.Len(t, w.batches[1], 1)
+	}
+	records := append(w.batches[0], w.batches[1]...) //nolint:gocritic
+	assert.Equal(t, "a", records[0].Key)
+	assert.Equal(t, 1, records[0].Value)
+	assert.ErrorIs(t, records[1].Err, errTest)
+	assert.ErrorIs(t, s.Watch("d", async.Future[int]{}), sink.ErrClosed)
+}
+
+func TestSinkRetry(t *testing.T) {
+	t.Parallel()
+
+	// given
+	w := recorder{fail: 1}
+	s := sink.New[int](context.Background(), &w, sink.WithRetry(2, time.Millisecond))
+	p, f := async.New[int]()
+	_ = s.Watch("a", f)
+
+	// when
+	p.Resolve(1)
+	err := s.Close(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, w.batches, 1)
+}
+
+func TestSinkDropped(t *testing.T) {
+	t.Parallel()
+
+	// given
+	w := recorder{fail: 2}
+	s := sink.New[int](context.Background(), &w, sink.WithRetry(2, time.Millisecond))
+	p, f := async.New[int]()
+	_ = s.Watch("a", f)
+
+	// when
+	p.Resolve(1)
+	err := s.Close(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, errTest)
+	assert.Empty(t, w.batches)
+}
+
+func TestSinkZeroFlushInterval(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var w recorder
+	s := sink.New[int](context.Background(), &w, sink.WithFlushInterval(0))
+	p, f := async.New[int]()
+	_ = s.Watch("a", f)
+
+	// when
+	p.Resolve(1)
+	err := s.Close(context.Background())
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, w.batches, 1)
+}
@@ -0,0 +1,74 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// StackTracer is implemented by errors carrying the call stack of the site where a promise was rejected.
+type StackTracer interface {
+	error
+	StackTrace() []runtime.Frame
+}
+
+var captureRejectStacks atomic.Bool
+
+// SetRejectStackTraces enables or disables wrapping errors passed to Reject with the current call stack, retrievable
+// through [StackTracer]. Capturing stacks is expensive, so it is disabled by default.
+func SetRejectStackTraces(enabled bool) {
+	captureRejectStacks.Store(enabled)
+}
+
+// withRejectStack wraps err with the stack of the caller of Reject or Do when enabled.
+func withRejectStack(err error) error {
+	if !captureRejectStacks.Load() || err == nil {
+		return err
+	}
+
+	const skip = 3 // runtime.Callers, withRejectStack, Reject or Do
+	var pcs [32]uintptr
+	n := runtime.Callers(skip, pcs[:])
+
+	return stackError{err: err, pcs: pcs[:n]}
+}
+
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+func (e stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e stackError) Unwrap() error {
+	return e.err
+}
+
+func (e stackError) StackTrace() []runtime.Frame {
+	frames := runtime.CallersFrames(e.pcs)
+	stack := make([]runtime.Frame, 0, len(e.pcs))
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			return stack
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func rejectHere(p async.Promise[int]) {
+	p.Reject(errTest)
+}
+
+func TestRejectStackTrace(t *testing.T) { //nolint:paralleltest // modifies global state
+	// given
+	async.SetRejectStackTraces(true)
+	defer async.SetRejectStackTraces(false)
+	p, f := async.New[int]()
+
+	// when
+	rejectHere(p)
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+	var st async.StackTracer
+	if assert.ErrorAs(t, err, &st) {
+		frames := st.StackTrace()
+		if assert.NotEmpty(t, frames) {
+			assert.True(t, strings.HasSuffix(frames[0].Function, ".rejectHere"), frames[0].Function)
+		}
+	}
+}
+
+func doHere(p async.Promise[int]) {
+	p.Do(func() (int, error) { return 0, errTest })
+}
+
+func guardedDoHere(p async.GuardedPromise[int]) {
+	p.Do(func() (int, error) { return 0, errTest })
+}
+
+func completerDoHere(c async.Completer) {
+	c.Do(func() error { return errTest })
+}
+
+func TestDoStackTrace(t *testing.T) { //nolint:paralleltest // modifies global state
+	// given
+	async.SetRejectStackTraces(true)
+	defer async.SetRejectStackTraces(false)
+	p, f := async.New[int]()
+	g, fg := async.NewGuarded[int](time.Minute)
+	c, fc := async.NewCompletion()
+
+	// when
+	doHere(p)
+	guardedDoHere(g)
+	completerDoHere(c)
+
+	// then
+	_, err := f.Try()
+	assertStackFrom(t, err, ".doHere")
+	_, err = fg.Try()
+	assertStackFrom(t, err, ".guardedDoHere")
+	assertStackFrom(t, fc.Try(), ".completerDoHere")
+}
+
+func assertStackFrom(t *testing.T, err error, function string) {
+	t.Helper()
+
+	assert.ErrorIs(t, err, errTest)
+	var st async.StackTracer
+	if assert.ErrorAs(t, err, &st) {
+		frames := st.StackTrace()
+		if assert.NotEmpty(t, frames) {
+			assert.True(t, strings.HasSuffix(frames[0].Function, function), frames[0].Function)
+		}
+	}
+}
+
+func TestRejectNoStackTrace(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	p.Reject(errTest)
+
+	// then
+	_, err := f.Try()
+	var st async.StackTracer
+	assert.False(t, errors.As(err, &st))
+}
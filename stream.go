@@ -0,0 +1,146 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// AwaitFromChannel returns a function that yields the results of futures received from futures, indexed in order of
+// arrival, as they complete. It keeps accepting new futures until the channel is closed and all received futures are
+// yielded. Futures that are ready at the same time are yielded in index order. If the context is canceled, it yields
+// the ready results, then an error for the remaining received futures, and stops receiving.
+func AwaitFromChannel[R any](
+	ctx context.Context, futures <-chan Future[R],
+) func(yield func(int, result.Result[R]) bool) {
+	return func(yield func(int, result.Result[R]) bool) {
+		s := &stream[R]{signal: make(chan struct{}, 1)}
+		s.yieldTo(ctx, futures, yield)
+	}
+}
+
+// stream tracks the futures received by [AwaitFromChannel].
+type stream[R any] struct {
+	_        noCopy
+	received []Future[R]
+	yielded  []bool
+	stops    []func()  // deregister callbacks of futures not yet complete
+	pending  indexHeap // indexes of ready futures
+
+	mu     sync.Mutex
+	ready  []int         // indexes of completed futures, guarded by mu
+	signal chan struct{} // notified when ready is non-empty
+}
+
+func (s *stream[R]) yieldTo(ctx context.Context, futures <-chan Future[R], yield func(int, result.Result[R]) bool) {
+	defer func() { // deregister from futures that were not yielded when stopping early
+		for _, stop := range s.stops {
+			if stop != nil {
+				stop()
+			}
+		}
+	}()
+
+	for outstanding := 0; futures != nil || outstanding > 0; {
+		select {
+		case f, ok := <-futures:
+			if !ok {
+				futures = nil
+
+				continue
+			}
+			s.add(f)
+			outstanding++
+
+		case <-s.signal:
+			n, ok := s.yieldReady(yield)
+			if !ok {
+				return
+			}
+			outstanding -= n
+
+		case <-ctx.Done():
+			if _, ok := s.yieldReady(yield); !ok {
+				return
+			}
+			e := result.OfError[R](cancelError(ctx, "channel yield"))
+			for idx, done := range s.yielded {
+				if !done && !yield(idx, e) {
+					return
+				}
+			}
+
+			return
+		}
+	}
+}
+
+// add registers a newly received future.
+func (s *stream[R]) add(f Future[R]) {
+	idx := len(s.received)
+	s.received = append(s.received, f)
+	s.yielded = append(s.yielded, false)
+	s.stops = append(s.stops, nil)
+
+	notify := func(result.Result[R]) {
+		s.mu.Lock()
+		s.ready = append(s.ready, idx)
+		s.mu.Unlock()
+
+		select {
+		case s.signal <- struct{}{}:
+		default:
+		}
+	}
+
+	if f.completed() {
+		notify(f.v)
+
+		return
+	}
+
+	cb := &callback[R]{fn: notify}
+	if !f.addCallback(cb) && cb.claim() {
+		cb.fn(f.v)
+	}
+	s.stops[idx] = func() { _ = f.removeCallback(cb) }
+}
+
+// yieldReady yields all completed futures in index order, returning their number and false when yield stopped.
+func (s *stream[R]) yieldReady(yield func(int, result.Result[R]) bool) (int, bool) {
+	s.mu.Lock()
+	for _, idx := range s.ready {
+		s.pending.push(idx)
+	}
+	s.ready = s.ready[:0]
+	s.mu.Unlock()
+
+	n := 0
+	for len(s.pending) > 0 {
+		idx := s.pending.pop()
+		s.yielded[idx], s.stops[idx] = true, nil
+		n++
+		if !yield(idx, s.received[idx].v) {
+			return n, false
+		}
+	}
+
+	return n, true
+}
@@ -0,0 +1,105 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitFromChannel(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ch := make(chan async.Future[int])
+	go func() {
+		defer close(ch)
+		for i := 0; i < 10; i++ {
+			i := i
+			ch <- async.NewAsync(func() (int, error) { return i, nil })
+		}
+	}()
+
+	// when
+	results := make(map[int]int)
+	async.AwaitFromChannel(context.Background(), ch)(func(idx int, r result.Result[int]) bool {
+		results[idx] = r.Value()
+
+		return true
+	})
+
+	// then
+	assert.Len(t, results, 10)
+	for idx, v := range results {
+		assert.Equal(t, idx, v)
+	}
+}
+
+func TestAwaitFromChannelCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan async.Future[int]) // unbuffered, so the resolve happens after both futures are received
+	_, f0 := async.New[int]()
+	p1, f1 := async.New[int]()
+	go func() {
+		ch <- f0
+		ch <- f1
+		p1.Resolve(1)
+	}()
+
+	// when
+	var indexes []int
+	var rs []result.Result[int]
+	async.AwaitFromChannel(ctx, ch)(func(idx int, r result.Result[int]) bool {
+		indexes = append(indexes, idx)
+		rs = append(rs, r)
+		cancel()
+
+		return true
+	})
+
+	// then
+	assert.Equal(t, []int{1, 0}, indexes)
+	if assert.Len(t, rs, 2) {
+		assert.Equal(t, 1, rs[0].Value())
+		assert.ErrorIs(t, rs[1].Err(), context.Canceled)
+	}
+}
+
+func TestAwaitFromChannelStopDeregisters(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ch := make(chan async.Future[int], 2)
+	_, f := async.New[int]()
+	ch <- f
+	ch <- resolved(1)
+	close(ch)
+
+	// when
+	async.AwaitFromChannel(context.Background(), ch)(func(int, result.Result[int]) bool { return false })
+
+	// then
+	assert.Zero(t, f.Stats().Callbacks)
+}
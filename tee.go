@@ -0,0 +1,44 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import "fillmore-labs.com/exp/async/result"
+
+// Tee returns two futures completed with the result of f. Each has its own awaiter accounting, callbacks and
+// derived futures, so two subsystems can consume one upstream result without coupling their lifecycles.
+func Tee[R any](f Future[R]) (Future[R], Future[R]) {
+	fs := TeeN(f, 2)
+
+	return fs[0], fs[1]
+}
+
+// TeeN is like [Tee], but returns n independent futures.
+func TeeN[R any](f Future[R], n int) []Future[R] {
+	promises := make([]Promise[R], n)
+	futures := make([]Future[R], n)
+	for i := range promises {
+		promises[i], futures[i] = New[R]()
+	}
+
+	f.onComplete(func(r result.Result[R]) {
+		for _, p := range promises {
+			p.complete(r)
+		}
+	})
+
+	return futures
+}
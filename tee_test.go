@@ -0,0 +1,68 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	a, b := async.Tee(f)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// when
+	go func() { _, _ = a.Await(ctx) }()
+	assert.Eventually(t, func() bool { return a.Stats().Awaiters == 1 }, time.Second, time.Millisecond)
+	awaitersB := b.Stats().Awaiters
+	p.Resolve(1)
+
+	// then
+	assert.Zero(t, awaitersB)
+	for _, g := range []async.Future[int]{a, b} {
+		if v, err := g.Await(ctx); assert.NoError(t, err) {
+			assert.Equal(t, 1, v)
+		}
+	}
+}
+
+func TestTeeN(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	fs := async.TeeN(f, 3)
+
+	// when
+	p.Reject(errTest)
+
+	// then
+	assert.Len(t, fs, 3)
+	for _, g := range fs {
+		_, err := g.Try()
+		assert.ErrorIs(t, err, errTest)
+	}
+}
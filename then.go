@@ -16,12 +16,17 @@
 
 package async
 
-import "fillmore-labs.com/exp/async/result"
+import (
+	"fmt"
+
+	"fillmore-labs.com/exp/async/result"
+)
 
 // Transform transforms the value of a successful [Future] synchronously into another, enabling i.e. unwrapping of
 // values.
 func Transform[R, S any](f Future[R], fn func(R, error) (S, error)) Future[S] {
 	ps, fs := New[S]()
+	dependsOn(fs.node(), f.node())
 
 	f.OnComplete(func(r result.Result[R]) {
 		ps.Do(func() (S, error) { return fn(r.V()) })
@@ -33,6 +38,7 @@ func Transform[R, S any](f Future[R], fn func(R, error) (S, error)) Future[S] {
 // AndThen executes fn asynchronously when future f completes, enabling chaining of operations.
 func AndThen[R, S any](f Future[R], fn func(R, error) (S, error)) Future[S] {
 	ps, fs := New[S]()
+	dependsOn(fs.node(), f.node())
 
 	f.OnComplete(func(r result.Result[R]) {
 		go ps.Do(func() (S, error) { return fn(r.V()) })
@@ -40,3 +46,181 @@ func AndThen[R, S any](f Future[R], fn func(R, error) (S, error)) Future[S] {
 
 	return fs
 }
+
+// Flatten returns a [Future] completing with the result of the inner future of f, or with the error of f when it
+// fails.
+func Flatten[R any](f Future[Future[R]]) Future[R] {
+	p, fr := New[R]()
+	dependsOn(fr.node(), f.node())
+
+	f.onComplete(func(r result.Result[Future[R]]) {
+		inner, err := r.V()
+		if err != nil {
+			p.complete(result.OfError[R](err))
+
+			return
+		}
+		p.completeWith(inner)
+	})
+
+	return fr
+}
+
+// FlatMap completes with the future returned by fn for the value of f, composing asynchronous steps. When f fails,
+// the result is rejected with its error and fn is not called.
+func FlatMap[R, S any](f Future[R], fn func(R) Future[S]) Future[S] {
+	ps, fs := New[S]()
+	dependsOn(fs.node(), f.node())
+
+	f.onComplete(func(r result.Result[R]) {
+		value, err := r.V()
+		if err != nil {
+			ps.complete(result.OfError[S](err))
+
+			return
+		}
+		ps.completeWith(fn(value))
+	})
+
+	return fs
+}
+
+// MapError returns a [Future] with the error of f transformed by fn, for example wrapping a storage error into a
+// domain error. Values pass through untouched and fn is only called on failure. When fn returns nil, the future
+// resolves with the value of the failed result.
+func MapError[R any](f Future[R], fn func(error) error) Future[R] {
+	return Transform(f, func(r R, err error) (R, error) {
+		if err != nil {
+			return r, fn(err)
+		}
+
+		return r, nil
+	})
+}
+
+// Finally returns a [Future] with the result of f that completes after fn ran, regardless of the outcome of f, for
+// releasing connections or closing spans.
+func Finally[R any](f Future[R], fn func()) Future[R] {
+	p, fr := New[R]()
+
+	f.onComplete(func(r result.Result[R]) {
+		fn()
+		p.complete(r)
+	})
+
+	return fr
+}
+
+// Tap returns a [Future] with the identical outcome of f that completes after fn observed the result, for logging or
+// metrics in a composable chain.
+func Tap[R any](f Future[R], fn func(r result.Result[R])) Future[R] {
+	p, fr := New[R]()
+
+	f.onComplete(func(r result.Result[R]) {
+		fn(r)
+		p.complete(r)
+	})
+
+	return fr
+}
+
+// TransformAll applies [Transform] to each future, returning the derived futures in the same order.
+func TransformAll[R, S any](futures []Future[R], fn func(R, error) (S, error)) []Future[S] {
+	derived := make([]Future[S], len(futures))
+	for i, f := range futures {
+		derived[i] = Transform(f, fn)
+	}
+
+	return derived
+}
+
+// AndThenAll applies [AndThen] to each future, returning the derived futures in the same order.
+// At most limit invocations of fn run concurrently on a [Limiter] like with [AndThenOn], so waiting invocations do
+// not hold goroutines. A limit less than 1 means no limit.
+func AndThenAll[R, S any](futures []Future[R], limit int, fn func(R, error) (S, error)) []Future[S] {
+	derived := make([]Future[S], len(futures))
+	if limit < 1 {
+		for i, f := range futures {
+			derived[i] = AndThen(f, fn)
+		}
+
+		return derived
+	}
+
+	l := NewLimiter(limit)
+	for i, f := range futures {
+		derived[i] = AndThenOn(f, l, fn)
+	}
+
+	return derived
+}
+
+// sharedKey distinguishes derived futures of different types registered under the same key.
+type sharedKey[S any, K comparable] struct {
+	key K
+}
+
+// TransformShared is like [Transform], but all calls with the same source future, key and result type share one
+// derived future, so fn runs only once. Only the fn of the first call is used. The shared future is forgotten once
+// the source future completes, so later calls run their fn on the completed result again.
+func TransformShared[R, S any, K comparable](f Future[R], key K, fn func(R, error) (S, error)) Future[S] {
+	k := sharedKey[S, K]{key: key}
+
+	f.mu.Lock()
+	if d, ok := f.derived[k]; ok {
+		f.mu.Unlock()
+
+		return d.(Future[S]) //nolint:forcetypeassert // keyed by type
+	}
+
+	ps, fs := New[S]()
+	if !f.completed() { // completion settles under f.mu, so the callback below forgets the entry
+		if f.derived == nil {
+			f.derived = make(map[any]any)
+		}
+		f.derived[k] = fs
+	}
+	f.mu.Unlock()
+
+	f.OnComplete(func(r result.Result[R]) {
+		ps.Do(func() (S, error) { return fn(r.V()) })
+		f.forgetDerived(k)
+	})
+
+	return fs
+}
+
+// StageError wraps an error with the name of the chain stage it surfaced in.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e StageError) Error() string {
+	return fmt.Sprintf("stage %q: %v", e.Stage, e.Err)
+}
+
+func (e StageError) Unwrap() error {
+	return e.Err
+}
+
+// TransformNamed is like [Transform], but wraps errors returned by fn in a [StageError] named stage.
+func TransformNamed[R, S any](stage string, f Future[R], fn func(R, error) (S, error)) Future[S] {
+	return Transform(f, named(stage, fn))
+}
+
+// AndThenNamed is like [AndThen], but wraps errors returned by fn in a [StageError] named stage.
+func AndThenNamed[R, S any](stage string, f Future[R], fn func(R, error) (S, error)) Future[S] {
+	return AndThen(f, named(stage, fn))
+}
+
+func named[R, S any](stage string, fn func(R, error) (S, error)) func(R, error) (S, error) {
+	return func(r R, err error) (S, error) {
+		s, err := fn(r, err)
+		if err != nil {
+			return s, StageError{Stage: stage, Err: err}
+		}
+
+		return s, nil
+	}
+}
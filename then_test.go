@@ -18,10 +18,14 @@ package async_test
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -117,3 +121,261 @@ func TestAndThen(t *testing.T) {
 		assert.Equal(t, "42", v)
 	}
 }
+
+func TestTransformAll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	promises, futures := makePromisesAndFutures[int]()
+	promises[0].Resolve(1)
+	promises[1].Reject(errTest)
+	promises[2].Resolve(3)
+
+	// when
+	derived := async.TransformAll(futures, itoa)
+
+	// then
+	results := async.AwaitAllResults(context.Background(), derived...)
+	assert.Equal(t, "1", results[0].Value())
+	assert.ErrorIs(t, results[1].Err(), errTest)
+	assert.Equal(t, "3", results[2].Value())
+}
+
+func TestAndThenAllLimit(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const limit = 2
+	promises, futures := makePromisesAndFutures[int]()
+
+	var running, maxRunning atomic.Int32
+	fn := func(i int, err error) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		return itoa(i, err)
+	}
+
+	// when
+	derived := async.AndThenAll(futures, limit, fn)
+	for i, p := range promises {
+		p.Resolve(i)
+	}
+
+	// then
+	values, err := async.AwaitAllValues(context.Background(), derived...)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"0", "1", "2"}, values)
+	}
+	assert.LessOrEqual(t, maxRunning.Load(), int32(limit))
+}
+
+func TestTransformNamed(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	f1 := async.TransformNamed("format", f, itoa)
+	f2 := async.AndThenNamed("parse", f1, func(s string, err error) (int, error) {
+		if err != nil {
+			return 0, err
+		}
+
+		return strconv.Atoi(s)
+	})
+	p.Resolve(-1)
+
+	// then
+	_, err := f2.Await(context.Background())
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, `stage "parse": stage "format": test error`)
+	var stageErr async.StageError
+	if assert.ErrorAs(t, err, &stageErr) {
+		assert.Equal(t, "parse", stageErr.Stage)
+	}
+}
+
+func TestTransformShared(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	var calls atomic.Int32
+	counted := func(i int, err error) (string, error) {
+		calls.Add(1)
+
+		return itoa(i, err)
+	}
+
+	// when
+	f1 := async.TransformShared(f, "itoa", counted)
+	f2 := async.TransformShared(f, "itoa", counted)
+	f3 := async.TransformShared(f, "other", counted)
+	p.Resolve(42)
+
+	// then
+	values, err := async.AwaitAllValues(context.Background(), f1, f2, f3)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"42", "42", "42"}, values)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestTransformSharedForgotten(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	_ = async.TransformShared(f, "itoa", itoa)
+	pending := async.DerivedFutures(f)
+
+	// when
+	p.Resolve(42)
+	late := async.TransformShared(f, "itoa", itoa)
+
+	// then
+	assert.Equal(t, 1, pending)
+	assert.Zero(t, async.DerivedFutures(f))
+	v, err := late.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "42", v)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	// given
+	po, outer := async.New[async.Future[int]]()
+	pi, inner := async.New[int]()
+
+	// when
+	f := async.Flatten(outer)
+	po.Resolve(inner)
+	_, pending := f.Try()
+	pi.Resolve(1)
+
+	// then
+	assert.ErrorIs(t, pending, async.ErrNotReady)
+	v, err := f.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestFlattenErrors(t *testing.T) {
+	t.Parallel()
+
+	// when
+	outerFailed := async.Flatten(rejected[async.Future[int]](errTest))
+	innerFailed := async.Flatten(resolved(rejected[int](errTest)))
+
+	// then
+	_, err := outerFailed.Try()
+	assert.ErrorIs(t, err, errTest)
+	_, err = innerFailed.Try()
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestFlatMap(t *testing.T) {
+	t.Parallel()
+
+	// given
+	lookup := func(id int) async.Future[string] {
+		return async.NewAsync(func() (string, error) { return "user" + strconv.Itoa(id), nil })
+	}
+
+	// when
+	f := async.FlatMap(resolved(7), lookup)
+
+	// then
+	v, err := f.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "user7", v)
+	}
+}
+
+func TestFlatMapFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	called := false
+
+	// when
+	f := async.FlatMap(rejected[int](errTest), func(int) async.Future[int] {
+		called = true
+
+		return resolved(1)
+	})
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, called)
+}
+
+func TestMapError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	errDomain := errors.New("domain error")
+	wrap := func(err error) error { return errors.Join(errDomain, err) }
+
+	// when
+	failed := async.MapError(rejected[int](errTest), wrap)
+	succeeded := async.MapError(resolved(1), wrap)
+
+	// then
+	_, err := failed.Try()
+	assert.ErrorIs(t, err, errDomain)
+	assert.ErrorIs(t, err, errTest)
+	v, err := succeeded.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestFinally(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+	released := 0
+
+	// when
+	fin := async.Finally(f, func() { released++ })
+	_, pending := fin.Try()
+	p.Reject(errTest)
+
+	// then
+	assert.ErrorIs(t, pending, async.ErrNotReady)
+	_, err := fin.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 1, released)
+}
+
+func TestTap(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var observed result.Result[int]
+
+	// when
+	f := async.Tap(resolved(1), func(r result.Result[int]) { observed = r })
+
+	// then
+	v, err := f.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+	assert.Equal(t, 1, observed.Value())
+}
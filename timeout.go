@@ -0,0 +1,71 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// ErrTimeout is wrapped by a [TimeoutError].
+var ErrTimeout = errors.New("timeout")
+
+// TimeoutError is returned when a future did not complete within the given duration. It matches [ErrTimeout] and
+// [ErrAwaitDeadline] with [errors.Is].
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("%v after %v", ErrTimeout, e.Timeout)
+}
+
+// Unwrap returns [ErrTimeout] and [ErrAwaitDeadline].
+func (e TimeoutError) Unwrap() []error {
+	return []error{ErrTimeout, ErrAwaitDeadline}
+}
+
+// WithTimeout returns a [Future] completing with the result of f when it arrives within d, otherwise rejected with a
+// [TimeoutError]. The timer is stopped as soon as f completes, so timeouts on many short-lived futures do not keep
+// pending timers around.
+func WithTimeout[R any](f Future[R], d time.Duration) Future[R] {
+	if f.completed() {
+		return f
+	}
+
+	p, fr := New[R]()
+
+	cb := &callback[R]{}
+	t := time.AfterFunc(d, func() {
+		if f.removeCallback(cb) {
+			p.Reject(TimeoutError{Timeout: d})
+		}
+	})
+	cb.fn = func(r result.Result[R]) {
+		t.Stop()
+		p.complete(r)
+	}
+
+	if !f.addCallback(cb) && cb.claim() {
+		cb.fn(f.v)
+	}
+
+	return fr
+}
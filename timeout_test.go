@@ -0,0 +1,107 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f := async.New[int]()
+
+	// when
+	timed := async.WithTimeout(f, time.Millisecond)
+
+	// then
+	_, err := timed.Await(context.Background())
+	var timeoutErr async.TimeoutError
+	if assert.ErrorAs(t, err, &timeoutErr) {
+		assert.Equal(t, time.Millisecond, timeoutErr.Timeout)
+	}
+	assert.ErrorIs(t, err, async.ErrTimeout)
+	assert.ErrorIs(t, err, async.ErrAwaitDeadline)
+	assert.Zero(t, f.Stats().Callbacks)
+}
+
+func TestWithTimeoutCompleted(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	timed := async.WithTimeout(f, time.Hour)
+	p.Resolve(1)
+
+	// then
+	v, err := timed.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestAwaitFor(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, f := async.New[int]()
+
+	// when
+	_, errTimeout := f.AwaitFor(time.Millisecond)
+	p.Resolve(1)
+	v, err := f.AwaitFor(time.Millisecond)
+
+	// then
+	var timeoutErr async.TimeoutError
+	if assert.ErrorAs(t, errTimeout, &timeoutErr) {
+		assert.Equal(t, time.Millisecond, timeoutErr.Timeout)
+	}
+	assert.NotErrorIs(t, errTimeout, context.DeadlineExceeded)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func BenchmarkWithTimeout(b *testing.B) {
+	b.ReportAllocs()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			p, f := async.New[int]()
+			_ = async.WithTimeout(f, time.Minute)
+			p.Resolve(i)
+		}
+	})
+}
+
+func BenchmarkAwaitFor(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		p, f := async.New[int]()
+		go p.Resolve(i)
+		_, _ = f.AwaitFor(time.Minute)
+	}
+}
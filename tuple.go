@@ -0,0 +1,245 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Await2 waits for two futures of different types, preserving their static types.
+// If any future fails, it returns early with an [AwaitError] identifying it.
+// If the context is canceled, it returns early with an error.
+func Await2[A, B any](ctx context.Context, fa Future[A], fb Future[B]) (A, B, error) {
+	if err := awaitTuple(ctx, fa, fb); err != nil {
+		return *new(A), *new(B), err
+	}
+
+	return fa.v.Value(), fb.v.Value(), nil
+}
+
+// Await3 is like [Await2] for three futures.
+func Await3[A, B, C any](ctx context.Context, fa Future[A], fb Future[B], fc Future[C]) (A, B, C, error) {
+	if err := awaitTuple(ctx, fa, fb, fc); err != nil {
+		return *new(A), *new(B), *new(C), err
+	}
+
+	return fa.v.Value(), fb.v.Value(), fc.v.Value(), nil
+}
+
+// Await4 is like [Await2] for four futures.
+func Await4[A, B, C, D any](
+	ctx context.Context, fa Future[A], fb Future[B], fc Future[C], fd Future[D],
+) (A, B, C, D, error) {
+	if err := awaitTuple(ctx, fa, fb, fc, fd); err != nil {
+		return *new(A), *new(B), *new(C), *new(D), err
+	}
+
+	return fa.v.Value(), fb.v.Value(), fc.v.Value(), fd.v.Value(), nil
+}
+
+// Destination binds a future to the variable receiving its value, see [Dest].
+type Destination struct {
+	future AnyFuture
+	store  func() error
+}
+
+// Dest returns a [Destination] writing the value of f into *dst when f succeeds.
+func Dest[R any](dst *R, f Future[R]) Destination {
+	return Destination{future: f, store: func() error {
+		v, err := f.v.V()
+		if err == nil {
+			*dst = v
+		}
+
+		return err
+	}}
+}
+
+// AwaitInto waits for all futures and writes each successful value into its destination, for arbitrary arities
+// without tuple types. It returns the joined errors of failed futures, each as an [AwaitError].
+// If the context is canceled, it returns early with an error.
+func AwaitInto(ctx context.Context, dests ...Destination) error {
+	futures := make([]AnyFuture, len(dests))
+	for i, d := range dests {
+		futures[i] = d.future
+	}
+
+	var errs []error
+	AwaitAllAny(ctx, futures...)(func(i int, _ result.Result[any]) bool {
+		if !futures[i].completed() {
+			errs = append(errs, cancelError(ctx, "await into"))
+
+			return false
+		}
+
+		if err := dests[i].store(); err != nil {
+			errs = append(errs, AwaitError{Index: i, Err: err})
+		}
+
+		return true
+	})
+
+	return errors.Join(errs...)
+}
+
+// awaitTuple waits until all futures succeeded, returning the first failure or cancellation.
+func awaitTuple(ctx context.Context, futures ...AnyFuture) error {
+	var err error
+	AwaitAllAny(ctx, futures...)(func(i int, r result.Result[any]) bool {
+		e := r.Err()
+		switch {
+		case e == nil:
+			return true
+
+		case ctx.Err() != nil:
+			err = cancelError(ctx, "await tuple")
+
+		default:
+			err = AwaitError{Index: i, Err: e}
+		}
+
+		return false
+	})
+
+	return err
+}
+
+// Pair holds the values of two futures joined by [Join2].
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple holds the values of three futures joined by [Join3].
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Quadruple holds the values of four futures joined by [Join4].
+type Quadruple[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// Quintuple holds the values of five futures joined by [Join5].
+type Quintuple[A, B, C, D, E any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+	Fifth  E
+}
+
+// Join2 returns a [Future] that resolves with the values of both futures when they succeed, or is rejected with an
+// [AwaitError] for the first failing one. Unlike [Await2] it does not block, so typed dependency graphs can be
+// built without converting to any.
+func Join2[A, B any](fa Future[A], fb Future[B]) Future[Pair[A, B]] {
+	return Transform(joinTuple(fa, fb), func(_ struct{}, err error) (Pair[A, B], error) {
+		if err != nil {
+			return Pair[A, B]{}, err
+		}
+
+		return Pair[A, B]{fa.v.Value(), fb.v.Value()}, nil
+	})
+}
+
+// Join3 is like [Join2] for three futures.
+func Join3[A, B, C any](fa Future[A], fb Future[B], fc Future[C]) Future[Triple[A, B, C]] {
+	return Transform(joinTuple(fa, fb, fc), func(_ struct{}, err error) (Triple[A, B, C], error) {
+		if err != nil {
+			return Triple[A, B, C]{}, err
+		}
+
+		return Triple[A, B, C]{fa.v.Value(), fb.v.Value(), fc.v.Value()}, nil
+	})
+}
+
+// Join4 is like [Join2] for four futures.
+func Join4[A, B, C, D any](fa Future[A], fb Future[B], fc Future[C], fd Future[D]) Future[Quadruple[A, B, C, D]] {
+	return Transform(joinTuple(fa, fb, fc, fd), func(_ struct{}, err error) (Quadruple[A, B, C, D], error) {
+		if err != nil {
+			return Quadruple[A, B, C, D]{}, err
+		}
+
+		return Quadruple[A, B, C, D]{fa.v.Value(), fb.v.Value(), fc.v.Value(), fd.v.Value()}, nil
+	})
+}
+
+// Join5 is like [Join2] for five futures.
+func Join5[A, B, C, D, E any](
+	fa Future[A], fb Future[B], fc Future[C], fd Future[D], fe Future[E],
+) Future[Quintuple[A, B, C, D, E]] {
+	return Transform(joinTuple(fa, fb, fc, fd, fe), func(_ struct{}, err error) (Quintuple[A, B, C, D, E], error) {
+		if err != nil {
+			return Quintuple[A, B, C, D, E]{}, err
+		}
+
+		return Quintuple[A, B, C, D, E]{fa.v.Value(), fb.v.Value(), fc.v.Value(), fd.v.Value(), fe.v.Value()}, nil
+	})
+}
+
+// joinTuple is like [WhenAllSucceed], but rejects with an [AwaitError] identifying the failing future.
+func joinTuple(futures ...AnyFuture) Future[struct{}] {
+	p, f := New[struct{}]()
+	if detectCycles.Load() {
+		sources := make([]any, len(futures))
+		for i, fut := range futures {
+			sources[i] = fut.node()
+		}
+		dependsOn(f.node(), sources...)
+	}
+
+	var remaining atomic.Int64
+	var failed atomic.Bool
+	remaining.Store(int64(len(futures)))
+	for i, fut := range futures {
+		i := i
+		fut.onSettled(func(err error) {
+			switch {
+			case err != nil:
+				if failed.CompareAndSwap(false, true) {
+					p.Reject(AwaitError{Index: i, Err: err})
+				}
+
+			case remaining.Add(-1) == 0 && !failed.Load():
+				p.Resolve(struct{}{})
+			}
+		})
+	}
+
+	return f
+}
+
+// Zip returns a [Future] resolving with the result of fn applied to the values of both futures once they succeed.
+// If either fails, it is rejected with an [AwaitError] without calling fn.
+func Zip[A, B, C any](fa Future[A], fb Future[B], fn func(A, B) (C, error)) Future[C] {
+	return Transform(joinTuple(fa, fb), func(_ struct{}, err error) (C, error) {
+		if err != nil {
+			return *new(C), err
+		}
+
+		return fn(fa.v.Value(), fb.v.Value())
+	})
+}
@@ -0,0 +1,242 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct{ name string }
+
+func TestAwait2(t *testing.T) {
+	t.Parallel()
+
+	// given
+	fu := async.NewAsync(func() (user, error) { return user{name: "ann"}, nil })
+	fo := async.NewAsync(func() ([]int, error) { return []int{1, 2}, nil })
+
+	// when
+	u, orders, err := async.Await2(context.Background(), fu, fo)
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ann", u.name)
+		assert.Equal(t, []int{1, 2}, orders)
+	}
+}
+
+func TestAwait3Failure(t *testing.T) {
+	t.Parallel()
+
+	// when
+	_, _, _, err := async.Await3(context.Background(), resolved(1), rejected[string](errTest), resolved(true))
+
+	// then
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 1, awaitErr.Index)
+	}
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestAwait4(t *testing.T) {
+	t.Parallel()
+
+	// when
+	a, b, c, d, err := async.Await4(context.Background(), resolved(1), resolved("b"), resolved(true), resolved(4.0))
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, a)
+		assert.Equal(t, "b", b)
+		assert.True(t, c)
+		assert.InDelta(t, 4.0, d, 0)
+	}
+}
+
+func TestAwait2Canceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, pending := async.New[int]()
+
+	// when
+	_, _, err := async.Await2(ctx, pending, pending)
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+}
+
+func TestAwaitInto(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var (
+		u user
+		n int
+		s string
+	)
+
+	// when
+	err := async.AwaitInto(context.Background(),
+		async.Dest(&u, resolved(user{name: "ann"})),
+		async.Dest(&n, resolved(2)),
+		async.Dest(&s, resolved("c")))
+
+	// then
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ann", u.name)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, "c", s)
+	}
+}
+
+func TestAwaitIntoFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var (
+		n int
+		s string
+	)
+
+	// when
+	err := async.AwaitInto(context.Background(),
+		async.Dest(&n, resolved(1)),
+		async.Dest(&s, rejected[string](errTest)))
+
+	// then
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 1, awaitErr.Index)
+	}
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, 1, n)
+}
+
+func TestAwaitIntoCanceled(t *testing.T) {
+	t.Parallel()
+
+	// given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var n int
+	_, pending := async.New[int]()
+
+	// when
+	err := async.AwaitInto(ctx, async.Dest(&n, pending))
+
+	// then
+	assert.ErrorIs(t, err, async.ErrAwaitCanceled)
+}
+
+func TestJoin2(t *testing.T) {
+	t.Parallel()
+
+	// given
+	pu, fu := async.New[user]()
+	po, fo := async.New[[]int]()
+
+	// when
+	joined := async.Join2(fu, fo)
+	po.Resolve([]int{1})
+	pu.Resolve(user{name: "ann"})
+
+	// then
+	pair, err := joined.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ann", pair.First.name)
+		assert.Equal(t, []int{1}, pair.Second)
+	}
+}
+
+func TestJoin3Failure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	pending, fp := async.New[bool]()
+
+	// when
+	joined := async.Join3(resolved(1), rejected[string](errTest), fp)
+
+	// then
+	_, err := joined.Try()
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 1, awaitErr.Index)
+	}
+	assert.ErrorIs(t, err, errTest)
+	pending.Resolve(true)
+}
+
+func TestJoin5(t *testing.T) {
+	t.Parallel()
+
+	// when
+	joined := async.Join5(resolved(1), resolved("b"), resolved(true), resolved(4.0), resolved(user{name: "e"}))
+
+	// then
+	v, err := joined.Await(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, async.Quintuple[int, string, bool, float64, user]{1, "b", true, 4.0, user{name: "e"}}, v)
+	}
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	// given
+	pa, fa := async.New[int]()
+	pb, fb := async.New[string]()
+
+	// when
+	zipped := async.Zip(fa, fb, func(a int, b string) (string, error) { return fmt.Sprint(b, a), nil })
+	pa.Resolve(1)
+	pb.Resolve("v")
+
+	// then
+	v, err := zipped.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "v1", v)
+	}
+}
+
+func TestZipFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	called := false
+
+	// when
+	zipped := async.Zip(resolved(1), rejected[int](errTest), func(a, b int) (int, error) {
+		called = true
+
+		return a + b, nil
+	})
+
+	// then
+	_, err := zipped.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, called)
+}
@@ -16,33 +16,347 @@
 
 package async
 
-import "fillmore-labs.com/exp/async/result"
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+const (
+	statePending uint32 = iota
+	stateComplete
+)
+
+// errAlreadyCompleted is the panic value when a promise is fulfilled twice.
+var errAlreadyCompleted = errors.New("promise already completed")
+
+// closedChan is returned by Done for futures that completed before anybody asked for a channel.
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+
+	return ch
+}()
 
 // value wraps a [Result] to enable multiple queries and avoid unnecessary recomputation.
+//
+// Fields are grouped by access pattern into two cache lines, so that pollers of a heavily shared future do not
+// suffer from false sharing with goroutines registering callbacks or blocking in Await.
 type value[R any] struct {
-	_     noCopy
-	done  chan struct{}                        // signals when future has completed
-	v     result.Result[R]                     // valid only when done is closed
-	queue chan []func(result result.Result[R]) // list of functions to execute synchronously when completed
+	_ noCopy
+
+	valueHead[R]
+	_ [headPadding]byte
+
+	// Written by registering and blocking goroutines.
+	mu        sync.Mutex                  // guards done, watchers, derived and purging callbacks
+	callbacks atomic.Pointer[callback[R]] // lock-free stack of functions to execute synchronously when completed
+	queued    atomic.Int32                // approximate number of stacked callbacks
+	removed   atomic.Int32                // approximate number of stacked callbacks claimed by removal
+	awaiters  atomic.Int32                // number of goroutines blocked in Await
+	watchers  []*func(int)                // called with the new number of awaiters on change, guarded by mu
+	derived   map[any]any                 // shared derived futures, guarded by mu
+	inline    atomic.Pointer[func()]      // task an awaiter may run instead of blocking, see SubmitInline
+}
+
+// valueHead holds the read-mostly fields of value, written at most once on completion.
+type valueHead[R any] struct {
+	state   atomic.Uint32                      // statePending or stateComplete
+	watched atomic.Bool                        // whether watchers is non-empty
+	done    chan struct{}                      // created on demand, closed on completion
+	v       result.Result[R]                   // valid only when state is stateComplete
+	erased  atomic.Pointer[result.Result[any]] // v converted to Result[any], computed on demand
+	sealed  callback[R]                        // swapped into callbacks on completion, only its address is used
+}
+
+// cacheLineSize is the assumed size of a CPU cache line, correct for amd64 and most arm64 cores.
+const cacheLineSize = 64
+
+// headPadding fills the cache line of valueHead. Its size does not depend on the type parameter, and is exactly one
+// cache line on 64-bit platforms, where no padding is needed.
+const headPadding = (cacheLineSize - unsafe.Sizeof(valueHead[struct{}]{})%cacheLineSize) % cacheLineSize
+
+// callback is a function registered for completion. It runs at most once, unless it is claimed by removal first.
+type callback[R any] struct {
+	fn      func(result result.Result[R])
+	claimed atomic.Bool
+	next    atomic.Pointer[callback[R]]
+}
+
+func (c *callback[_]) claim() bool {
+	return c.claimed.CompareAndSwap(false, true)
+}
+
+func (r *value[R]) completed() bool {
+	return r.state.Load() == stateComplete
 }
 
 func (r *value[R]) complete(value result.Result[R]) {
+	if !r.tryComplete(value) {
+		panic(errAlreadyCompleted)
+	}
+}
+
+// tryComplete completes the value, returning false if it was already complete.
+func (r *value[R]) tryComplete(value result.Result[R]) bool {
+	head, done, ok := r.settle(value)
+	if ok {
+		r.notify(head, done)
+	}
+
+	return ok
+}
+
+// settle stores value and seals the callbacks without running them, returning false if the value was already
+// complete. The caller must pass the returned stack and channel to notify.
+func (r *value[R]) settle(value result.Result[R]) (head *callback[R], done chan struct{}, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.completed() {
+		return nil, nil, false
+	}
+
 	r.v = value
-	close(r.done)
+	r.state.Store(stateComplete)
+	head = r.callbacks.Swap(&r.sealed) // under r.mu, so a running purge has reattached its callbacks
 
-	queue := <-r.queue
-	close(r.queue)
+	return head, r.done, true
+}
 
-	for _, fn := range queue {
-		fn(value)
+// notify wakes blocked awaiters and runs the callbacks sealed by settle.
+func (r *value[R]) notify(head *callback[R], done chan struct{}) {
+	forgetDependencies(r)
+	if done != nil {
+		close(done)
 	}
+	runCallbacks(head, r.v)
 }
 
 func (r *value[R]) onComplete(fn func(value result.Result[R])) {
-	if queue, ok := <-r.queue; ok {
-		queue = append(queue, fn)
-		r.queue <- queue
-	} else {
+	if r.completed() || !r.addCallback(&callback[R]{fn: fn}) {
 		fn(r.v)
 	}
 }
+
+// onCompleteCtx executes fn when the value is complete, or canceled when the context is done first.
+// Exactly one of both functions is called, and the registration is removed when the context is done.
+func (r *value[R]) onCompleteCtx(ctx context.Context, fn func(value result.Result[R]), canceled func()) {
+	switch {
+	case ctx.Err() != nil:
+		canceled()
+
+		return
+
+	case r.completed():
+		fn(r.v)
+
+		return
+	}
+
+	cb := &callback[R]{}
+	stop := context.AfterFunc(ctx, func() {
+		if r.removeCallback(cb) {
+			canceled()
+		}
+	})
+	cb.fn = func(value result.Result[R]) {
+		_ = stop()
+		if ctx.Err() != nil { // AfterFunc runs asynchronously and might not have been able to claim cb yet
+			canceled()
+
+			return
+		}
+		fn(value)
+	}
+
+	if !r.addCallback(cb) && cb.claim() {
+		cb.fn(r.v)
+	}
+}
+
+// addCallback queues cb for execution on completion. It returns false when the value is already complete.
+func (r *value[R]) addCallback(cb *callback[R]) bool {
+	for head := r.callbacks.Load(); ; head = r.callbacks.Load() {
+		if head == &r.sealed {
+			return false
+		}
+		cb.next.Store(head)
+		if r.callbacks.CompareAndSwap(head, cb) {
+			r.queued.Add(1)
+
+			return true
+		}
+	}
+}
+
+// removeCallback claims cb. It returns false when cb already ran or was removed.
+// Claimed callbacks stay stacked until enough of them accumulate to be worth purging.
+func (r *value[R]) removeCallback(cb *callback[R]) bool {
+	if !cb.claim() {
+		return false
+	}
+
+	const minPurge = 16
+	if removed := r.removed.Add(1); removed >= minPurge && 2*removed > r.queued.Load() {
+		r.purgeCallbacks()
+	}
+
+	return true
+}
+
+// purgeCallbacks drops claimed callbacks from the stack. Holding r.mu excludes completion, so the detached callbacks
+// are always reattached, below the ones registered meanwhile to keep registration order.
+func (r *value[R]) purgeCallbacks() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	head := r.callbacks.Load()
+	if head == &r.sealed || !r.callbacks.CompareAndSwap(head, nil) {
+		return // completed or contended, retry on a later removal
+	}
+
+	var first, last *callback[R]
+	var seen, kept int32
+	for cb := head; cb != nil; cb = cb.next.Load() {
+		seen++
+		if cb.claimed.Load() {
+			continue
+		}
+		if last == nil {
+			first = cb
+		} else {
+			last.next.Store(cb)
+		}
+		last = cb
+		kept++
+	}
+	r.removed.Store(0)
+	r.queued.Add(kept - seen) // keep registrations counted meanwhile
+	if first == nil {
+		return
+	}
+	last.next.Store(nil)
+
+	if r.callbacks.CompareAndSwap(nil, first) {
+		return
+	}
+
+	// Registrations only push on top, so the bottom of the new stack is stable.
+	tail := r.callbacks.Load()
+	for next := tail.next.Load(); next != nil; next = tail.next.Load() {
+		tail = next
+	}
+	tail.next.Store(first)
+}
+
+func runCallbacks[R any](head *callback[R], value result.Result[R]) {
+	var prev *callback[R]
+	for cb := head; cb != nil; { // reverse in place, we own the detached stack
+		next := cb.next.Load()
+		cb.next.Store(prev)
+		prev, cb = cb, next
+	}
+
+	for cb := prev; cb != nil; cb = cb.next.Load() {
+		if cb.claim() {
+			cb.fn(value)
+		}
+	}
+}
+
+// pendingCallbacks returns the number of callbacks waiting to run.
+func (r *value[R]) pendingCallbacks() int {
+	var n int
+	for cb := r.callbacks.Load(); cb != nil && cb != &r.sealed; cb = cb.next.Load() {
+		if !cb.claimed.Load() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// forgetDerived drops the shared derived future registered under k.
+func (r *value[R]) forgetDerived(k any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.derived, k)
+	if len(r.derived) == 0 {
+		r.derived = nil
+	}
+}
+
+// doneChan returns a channel that is closed on completion, creating it when necessary.
+func (r *value[R]) doneChan() <-chan struct{} {
+	if r.completed() {
+		return closedChan
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.completed() {
+		return closedChan
+	}
+	if r.done == nil {
+		r.done = make(chan struct{})
+	}
+
+	return r.done
+}
+
+// node identifies the value in the dependency graph of cycle detection.
+func (r *value[R]) node() any {
+	return r
+}
+
+// runInline runs the task of a future created by [SubmitInline] on the calling goroutine, unless a worker already
+// started it.
+func (r *value[R]) runInline() {
+	if task := r.inline.Load(); task != nil {
+		(*task)()
+	}
+}
+
+// addAwaiter changes the number of blocked awaiters by delta and notifies watchers.
+func (r *value[R]) addAwaiter(delta int32) {
+	n := int(r.awaiters.Add(delta))
+	if !r.watched.Load() {
+		return
+	}
+
+	r.mu.Lock()
+	watchers := slices.Clone(r.watchers)
+	r.mu.Unlock()
+
+	for _, fn := range watchers {
+		(*fn)(n)
+	}
+}
+
+// watchAwaiters registers fn to be called whenever the number of blocked awaiters changes.
+func (r *value[R]) watchAwaiters(fn func(n int)) (stop func()) {
+	w := &fn
+
+	r.mu.Lock()
+	r.watchers = append(r.watchers, w)
+	r.watched.Store(true)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if idx := slices.Index(r.watchers, w); idx >= 0 {
+			r.watchers = slices.Delete(r.watchers, idx, idx+1)
+		}
+		r.watched.Store(len(r.watchers) > 0)
+	}
+}
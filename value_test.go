@@ -0,0 +1,276 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentCompletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const iterations = 100
+	p, f := async.New[int]()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(3 * iterations)
+
+	var mu sync.Mutex
+	var values []int
+
+	// when
+	for i := 0; i < iterations; i++ {
+		go func() {
+			defer wg.Done()
+			<-f.Done()
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = f.Await(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			f.OnComplete(func(r result.Result[int]) {
+				mu.Lock()
+				defer mu.Unlock()
+				values = append(values, r.Value())
+			})
+		}()
+	}
+	p.Resolve(1)
+	wg.Wait()
+
+	// then
+	assert.Len(t, values, iterations)
+	for _, v := range values {
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestRemovedCallbacksPurged(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const registrations, minPurge = 1_000, 16
+	p, f := async.New[int]()
+	var order []int
+
+	// when
+	for i := 0; i < registrations; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		f.OnCompleteCtx(ctx, func(result.Result[int]) { t.Error("canceled callback called") })
+		cancel()
+		if i%100 == 0 {
+			i := i
+			f.OnComplete(func(result.Result[int]) { order = append(order, i) })
+		}
+	}
+	assert.Eventually(t, func() bool { return f.Stats().Callbacks == 10 }, time.Second, time.Millisecond)
+	stacked := async.StackedCallbacks(f)
+	p.Resolve(1)
+
+	// then
+	assert.Less(t, stacked, 10+minPurge) // removed callbacks do not accumulate
+	assert.Equal(t, []int{0, 100, 200, 300, 400, 500, 600, 700, 800, 900}, order)
+}
+
+func TestPurgeDuringCompletion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const goroutines, registrations = 8, 500
+	p, f := async.New[int]()
+
+	var mu sync.Mutex
+	order := make([][]int, goroutines)
+
+	halfway := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		g := g
+		go func() {
+			defer wg.Done()
+			for i := 0; i < registrations; i++ {
+				if g == 0 && i == registrations/2 {
+					close(halfway)
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				f.OnCompleteCtx(ctx, func(result.Result[int]) {})
+				cancel()
+				i := i
+				f.OnComplete(func(result.Result[int]) {
+					mu.Lock()
+					order[g] = append(order[g], i)
+					mu.Unlock()
+				})
+			}
+		}()
+	}
+
+	// when
+	<-halfway
+	p.Resolve(1)
+	wg.Wait()
+
+	// then
+	for g := 0; g < goroutines; g++ {
+		if assert.Len(t, order[g], registrations) {
+			assert.IsIncreasing(t, order[g])
+		}
+	}
+}
+
+func TestDoubleResolve(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p, _ := async.New[int]()
+	p.Resolve(1)
+
+	// when
+	resolve := func() { p.Resolve(2) }
+
+	// then
+	assert.Panics(t, resolve)
+}
+
+func BenchmarkNewResolve(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p, f := async.New[int]()
+		p.Resolve(i)
+		_, _ = f.Try()
+	}
+}
+
+func BenchmarkAwaitCompleted(b *testing.B) {
+	p, f := async.New[int]()
+	p.Resolve(1)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = f.Await(ctx)
+	}
+}
+
+func BenchmarkTryPending(b *testing.B) {
+	_, f := async.New[int]()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = f.Try()
+	}
+}
+
+func BenchmarkTryCompleted(b *testing.B) {
+	p, f := async.New[int]()
+	p.Resolve(1)
+
+	for i := 0; i < b.N; i++ {
+		_, _ = f.Try()
+	}
+}
+
+func BenchmarkIsDone(b *testing.B) {
+	_, f := async.New[int]()
+
+	for i := 0; i < b.N; i++ {
+		_ = f.IsDone()
+	}
+}
+
+func BenchmarkAwaitPending(b *testing.B) {
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		p, f := async.New[int]()
+		go p.Resolve(i)
+		_, _ = f.Await(ctx)
+	}
+}
+
+func BenchmarkOnComplete(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p, f := async.New[int]()
+		f.OnComplete(func(result.Result[int]) {})
+		p.Resolve(i)
+	}
+}
+
+func BenchmarkOnCompleteContended(b *testing.B) {
+	p, f := async.New[int]()
+	defer p.Resolve(0)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			f.OnComplete(func(result.Result[int]) {})
+		}
+	})
+}
+
+func BenchmarkIsDoneContended(b *testing.B) {
+	p, f := async.New[int]()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() { // keep the write-hot fields busy by registering and removing callbacks
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+
+			default:
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			f.OnCompleteCtx(ctx, func(result.Result[int]) {})
+			cancel()
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = f.IsDone()
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	wg.Wait()
+	p.Resolve(0)
+}
+
+func BenchmarkAwaitSpinPending(b *testing.B) {
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		p, f := async.New[int]()
+		go p.Resolve(i)
+		_, _ = f.AwaitSpin(ctx, 10*time.Microsecond)
+	}
+}
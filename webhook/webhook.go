@@ -0,0 +1,182 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook completes futures from external HTTP callbacks.
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+)
+
+// TokenParam is the query parameter identifying the pending promise in callback URLs.
+const TokenParam = "token"
+
+// ErrExpired is returned by futures whose callback did not arrive within the time to live of their token.
+var ErrExpired = errors.New("webhook token expired")
+
+// Decoder parses the payload of a callback request.
+type Decoder[R any] func(req *http.Request) (R, error)
+
+// JSONDecoder decodes a JSON request body.
+func JSONDecoder[R any](req *http.Request) (R, error) {
+	var v R
+	err := json.NewDecoder(req.Body).Decode(&v)
+
+	return v, err
+}
+
+// Option configures a [Handler].
+type Option func(opts *options)
+
+type options struct {
+	maxBody int64
+	ttl     time.Duration
+}
+
+// WithMaxBodySize limits callback payloads to size bytes, 1 MiB by default. Larger payloads are answered with 413.
+func WithMaxBodySize(size int64) Option {
+	return func(opts *options) { opts.maxBody = max(size, 1) }
+}
+
+// WithTTL sets the time after which a registered token without callback expires, rejecting its future with
+// [ErrExpired], 24 hours by default. A ttl that is not positive disables expiry.
+func WithTTL(ttl time.Duration) Option {
+	return func(opts *options) { opts.ttl = ttl }
+}
+
+// Handler is an [http.Handler] completing registered promises with the decoded payload of callbacks.
+type Handler[R any] struct {
+	decode  Decoder[R]
+	opts    options
+	mu      sync.Mutex
+	pending map[string]registration[R]
+}
+
+type registration[R any] struct {
+	p      async.Promise[R]
+	expiry *time.Timer // nil without expiry
+}
+
+// NewHandler creates a [Handler] using decode to parse callback payloads.
+func NewHandler[R any](decode Decoder[R], opts ...Option) *Handler[R] {
+	o := options{maxBody: 1 << 20, ttl: 24 * time.Hour}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Handler[R]{decode: decode, opts: o, pending: make(map[string]registration[R])}
+}
+
+// Register creates a pending promise and returns the token to pass to the external system in the callback URL,
+// together with a future completed by the callback.
+func (h *Handler[R]) Register() (string, async.Future[R], error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", async.Future[R]{}, err
+	}
+	token := hex.EncodeToString(b[:])
+
+	p, f := async.New[R]()
+	r := registration[R]{p: p}
+
+	h.mu.Lock()
+	if h.opts.ttl > 0 {
+		r.expiry = time.AfterFunc(h.opts.ttl, func() { _ = h.Cancel(token, ErrExpired) })
+	}
+	h.pending[token] = r
+	h.mu.Unlock()
+
+	return token, f, nil
+}
+
+// Cancel rejects the promise registered under token with err, for example after a timeout.
+// It returns false when the token is unknown or already completed.
+func (h *Handler[R]) Cancel(token string, err error) bool {
+	p, ok := h.take(token)
+	if ok {
+		p.Reject(err)
+	}
+
+	return ok
+}
+
+// ServeHTTP handles callbacks. Unknown or expired tokens are answered with 404, undecodable payloads with 400 and
+// oversized ones with 413, leaving the promise pending so the external system can retry.
+func (h *Handler[R]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost && req.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	token := req.URL.Query().Get(TokenParam)
+
+	h.mu.Lock()
+	_, ok := h.pending[token]
+	h.mu.Unlock()
+	if !ok {
+		http.NotFound(w, req)
+
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, h.opts.maxBody)
+	v, err := h.decode(req)
+	if err != nil {
+		code := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			code = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), code)
+
+		return
+	}
+
+	p, ok := h.take(token)
+	if !ok { // completed concurrently
+		http.NotFound(w, req)
+
+		return
+	}
+	p.Resolve(v)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler[R]) take(token string) (async.Promise[R], bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.pending[token]
+	if !ok {
+		return async.Promise[R]{}, false
+	}
+	delete(h.pending, token)
+	if r.expiry != nil {
+		r.expiry.Stop()
+	}
+
+	return r.p, true
+}
@@ -0,0 +1,121 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("test error")
+
+type status struct {
+	State string `json:"state"`
+}
+
+func callback(h http.Handler, token, body string) int {
+	req := httptest.NewRequest(http.MethodPost, "/cb?"+webhook.TokenParam+"="+token, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestCallback(t *testing.T) {
+	t.Parallel()
+
+	// given
+	h := webhook.NewHandler(webhook.JSONDecoder[status])
+	token, f, err := h.Register()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// when
+	bad := callback(h, token, "{")
+	_, pending := f.Try()
+	good := callback(h, token, `{"state":"done"}`)
+	again := callback(h, token, `{"state":"done"}`)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, bad)
+	assert.ErrorIs(t, pending, async.ErrNotReady)
+	assert.Equal(t, http.StatusNoContent, good)
+	assert.Equal(t, http.StatusNotFound, again)
+	v, err := f.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "done", v.State)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	t.Parallel()
+
+	// given
+	h := webhook.NewHandler(webhook.JSONDecoder[status])
+	token, f, _ := h.Register()
+
+	// when
+	canceled := h.Cancel(token, errTest)
+
+	// then
+	assert.True(t, canceled)
+	assert.False(t, h.Cancel(token, errTest))
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, http.StatusNotFound, callback(h, token, "{}"))
+}
+
+func TestExpired(t *testing.T) {
+	t.Parallel()
+
+	// given
+	h := webhook.NewHandler(webhook.JSONDecoder[status], webhook.WithTTL(time.Millisecond))
+	token, f, _ := h.Register()
+
+	// when
+	_, err := f.Await(context.Background())
+
+	// then
+	assert.ErrorIs(t, err, webhook.ErrExpired)
+	assert.Equal(t, http.StatusNotFound, callback(h, token, `{"state":"done"}`))
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	// given
+	h := webhook.NewHandler(webhook.JSONDecoder[status], webhook.WithMaxBodySize(8))
+	token, f, _ := h.Register()
+
+	// when
+	code := callback(h, token, `{"state":"done"}`)
+
+	// then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	_, err := f.Try()
+	assert.ErrorIs(t, err, async.ErrNotReady)
+}
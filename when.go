@@ -0,0 +1,152 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"sync/atomic"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// WhenAll returns a [Future] that resolves when all futures are complete, regardless of their outcome.
+func WhenAll(futures ...AnyFuture) Future[struct{}] {
+	p, f := New[struct{}]()
+	if len(futures) == 0 {
+		p.Resolve(struct{}{})
+
+		return f
+	}
+
+	var remaining atomic.Int64
+	remaining.Store(int64(len(futures)))
+	for _, fut := range futures {
+		fut.onSettled(func(error) {
+			if remaining.Add(-1) == 0 {
+				p.Resolve(struct{}{})
+			}
+		})
+	}
+
+	return f
+}
+
+// WhenAllSucceed returns a [Future] that resolves when all futures succeed, or is rejected with the error of the
+// first failing one.
+func WhenAllSucceed(futures ...AnyFuture) Future[struct{}] {
+	p, f := New[struct{}]()
+	if len(futures) == 0 {
+		p.Resolve(struct{}{})
+
+		return f
+	}
+
+	var remaining atomic.Int64
+	var failed atomic.Bool
+	remaining.Store(int64(len(futures)))
+	for _, fut := range futures {
+		fut.onSettled(func(err error) {
+			switch {
+			case err != nil:
+				if failed.CompareAndSwap(false, true) {
+					p.Reject(err)
+				}
+
+			case remaining.Add(-1) == 0 && !failed.Load():
+				p.Resolve(struct{}{})
+			}
+		})
+	}
+
+	return f
+}
+
+// WhenAllValues returns a [Future] that resolves with the values of all futures in argument order, or is rejected
+// with an [AwaitError] for the first failing one. It does not block, so the aggregate can be transformed or combined
+// further.
+func WhenAllValues[R any](futures ...Future[R]) Future[[]R] {
+	p, f := New[[]R]()
+	values := make([]R, len(futures))
+	if len(futures) == 0 {
+		p.Resolve(values)
+
+		return f
+	}
+
+	var remaining atomic.Int64
+	var failed atomic.Bool
+	remaining.Store(int64(len(futures)))
+	for i, fut := range futures {
+		i := i
+		fut.onComplete(func(r result.Result[R]) {
+			switch {
+			case r.Err() != nil:
+				if failed.CompareAndSwap(false, true) {
+					p.Reject(AwaitError{Index: i, Err: r.Err()})
+				}
+
+			default:
+				values[i] = r.Value()
+				if remaining.Add(-1) == 0 && !failed.Load() {
+					p.Resolve(values)
+				}
+			}
+		})
+	}
+
+	return f
+}
+
+// FirstError returns a [Future] that resolves with the first rejection observed among futures, or with nil when all
+// succeed, so supervisors can await "anything went wrong" alongside their main results.
+func FirstError(futures ...AnyFuture) Future[error] {
+	return firstError(true, futures)
+}
+
+// FirstFailure is like [FirstError], but never resolves when all futures succeed.
+func FirstFailure(futures ...AnyFuture) Future[error] {
+	return firstError(false, futures)
+}
+
+func firstError(resolveOnSuccess bool, futures []AnyFuture) Future[error] {
+	p, f := New[error]()
+	if len(futures) == 0 {
+		if resolveOnSuccess {
+			p.Resolve(nil)
+		}
+
+		return f
+	}
+
+	var remaining atomic.Int64
+	var settled atomic.Bool
+	remaining.Store(int64(len(futures)))
+	for _, fut := range futures {
+		fut.onSettled(func(err error) {
+			switch {
+			case err != nil:
+				if settled.CompareAndSwap(false, true) {
+					p.Resolve(err)
+				}
+
+			case remaining.Add(-1) == 0 && resolveOnSuccess && settled.CompareAndSwap(false, true):
+				p.Resolve(nil)
+			}
+		})
+	}
+
+	return f
+}
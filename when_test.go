@@ -0,0 +1,155 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhenAll(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[string]()
+
+	// when
+	f := async.WhenAll(f1, f2)
+	p1.Reject(errTest)
+	_, err1 := f.Try()
+	p2.Resolve("test")
+	_, err2 := f.Try()
+
+	// then
+	assert.ErrorIs(t, err1, async.ErrNotReady)
+	assert.NoError(t, err2)
+}
+
+func TestWhenAllEmpty(t *testing.T) {
+	t.Parallel()
+
+	// when
+	f := async.WhenAll()
+
+	// then
+	_, err := f.Try()
+	assert.NoError(t, err)
+}
+
+func TestWhenAllSucceed(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[string]()
+
+	// when
+	f := async.WhenAllSucceed(f1, f2)
+	p1.Resolve(1)
+	p2.Resolve("test")
+
+	// then
+	_, err := f.Try()
+	assert.NoError(t, err)
+}
+
+func TestWhenAllSucceedFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	_, f1 := async.New[int]()
+	p2, f2 := async.New[string]()
+
+	// when
+	f := async.WhenAllSucceed(f1, f2)
+	p2.Reject(errTest)
+
+	// then
+	_, err := f.Try()
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestFirstError(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[string]()
+	p3, f3 := async.New[int]()
+	p4, f4 := async.New[int]()
+
+	// when
+	failed := async.FirstError(f1, f2)
+	succeeded := async.FirstError(f3, f4)
+	strict := async.FirstFailure(f3, f4)
+	p1.Resolve(1)
+	p2.Reject(errTest)
+	p3.Resolve(3)
+	p4.Resolve(4)
+
+	// then
+	if err, e := failed.Try(); assert.NoError(t, e) {
+		assert.ErrorIs(t, err, errTest)
+	}
+	if err, e := succeeded.Try(); assert.NoError(t, e) {
+		assert.NoError(t, err)
+	}
+	assert.False(t, strict.IsDone())
+}
+
+func TestWhenAllValues(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+
+	// when
+	all := async.WhenAllValues(f1, f2)
+	p2.Resolve(2)
+	p1.Resolve(1)
+
+	// then
+	values, err := all.Try()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []int{1, 2}, values)
+	}
+}
+
+func TestWhenAllValuesFailure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	p1, f1 := async.New[int]()
+	p2, f2 := async.New[int]()
+
+	// when
+	all := async.WhenAllValues(f1, f2)
+	p2.Reject(errTest)
+
+	// then
+	_, err := all.Try()
+	var awaitErr async.AwaitError
+	if assert.ErrorAs(t, err, &awaitErr) {
+		assert.Equal(t, 1, awaitErr.Index)
+	}
+	assert.ErrorIs(t, err, errTest)
+	p1.Resolve(1)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async
+
+import (
+	"context"
+	"sync"
+
+	"fillmore-labs.com/exp/async/result"
+)
+
+// Window returns a function that runs fn for every task with at most n calls in flight, yielding the results in
+// task order. Tasks are consumed only as the window advances, so tasks may be an unbounded stream.
+// When the consumer stops early or the context is canceled, no further tasks are started and the context passed to
+// calls still in flight is canceled; the function returns after they are complete.
+func Window[T, R any](
+	ctx context.Context, n int, tasks func(yield func(T) bool), fn func(ctx context.Context, task T) (R, error),
+) func(yield func(int, result.Result[R]) bool) {
+	if n < 1 {
+		n = 1
+	}
+
+	return func(yield func(int, result.Result[R]) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		window := make([]Future[R], 0, n)
+		next := 0 // task index of window[0]
+		emit := func() bool {
+			f := window[0]
+			window = append(window[:0], window[1:]...)
+			idx := next
+			next++
+
+			return yield(idx, result.Of(f.Await(ctx)))
+		}
+
+		stopped := false
+		tasks(func(task T) bool {
+			if len(window) == n && !emit() {
+				stopped = true
+
+				return false
+			}
+
+			p, f := New[R]()
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.Do(func() (R, error) { return fn(ctx, task) })
+			}()
+			window = append(window, f)
+
+			return ctx.Err() == nil
+		})
+
+		for !stopped && len(window) > 0 {
+			stopped = !emit()
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023-2024 Oliver Eikemeier. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package async_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"fillmore-labs.com/exp/async"
+	"fillmore-labs.com/exp/async/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func count(n int) func(yield func(int) bool) {
+	return func(yield func(int) bool) {
+		for i := 0; i < n && yield(i); i++ {
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	// given
+	const n = 3
+	var running, maxRunning atomic.Int32
+	fn := func(_ context.Context, i int) (int, error) {
+		r := running.Add(1)
+		defer running.Add(-1)
+		for m := maxRunning.Load(); r > m && !maxRunning.CompareAndSwap(m, r); m = maxRunning.Load() {
+		}
+		if i == 5 {
+			return 0, errTest
+		}
+
+		return i * i, nil
+	}
+
+	// when
+	var values []int
+	var errIdx []int
+	async.Window(context.Background(), n, count(10), fn)(func(i int, r result.Result[int]) bool {
+		if r.Err() != nil {
+			errIdx = append(errIdx, i)
+		} else {
+			values = append(values, r.Value())
+		}
+
+		return true
+	})
+
+	// then
+	assert.Equal(t, []int{0, 1, 4, 9, 16, 36, 49, 64, 81}, values)
+	assert.Equal(t, []int{5}, errIdx)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(n))
+}
+
+func TestWindowStop(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var started atomic.Int32
+	fn := func(ctx context.Context, i int) (int, error) {
+		started.Add(1)
+		if i > 0 {
+			<-ctx.Done()
+		}
+
+		return i, nil
+	}
+
+	// when
+	var yielded int
+	async.Window(context.Background(), 2, count(1_000), fn)(func(int, result.Result[int]) bool {
+		yielded++
+
+		return false
+	})
+
+	// then
+	assert.Equal(t, 1, yielded)
+	assert.LessOrEqual(t, started.Load(), int32(3))
+}